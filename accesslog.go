@@ -0,0 +1,144 @@
+package minirouter
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AccessLog returns a Middleware logging each request with logger once it has been served. The record has the
+// following attributes: method, route (the path pattern of the matched route, eg. "/users/:id", or an empty string),
+// status, bytes (the size of the response's body), latency, remote_addr and request_id (see RequestIDMiddleware).
+// Requests answered with a 5xx status code are logged at the Error level, the others at the Info level.
+//
+// Added with Use, AccessLog also logs the requests that match no route. It should be added after
+// RequestIDMiddleware, although the request ID is also read from the response's X-Request-ID header otherwise.
+func AccessLog(logger *slog.Logger) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			start := time.Now()
+			rw := NewResponseWriter(w)
+			next.ServeHTTP(rw, req)
+
+			status := rw.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			level := slog.LevelInfo
+			if status >= 500 {
+				level = slog.LevelError
+			}
+			requestID := RequestID(req)
+			if requestID == "" {
+				requestID = rw.Header().Get(RequestIDHeader)
+			}
+
+			logger.LogAttrs(req.Context(), level, "request",
+				slog.String("method", req.Method),
+				slog.String("route", routePattern(req)),
+				slog.Int("status", status),
+				slog.Int64("bytes", rw.BytesWritten()),
+				slog.Duration("latency", time.Since(start)),
+				slog.String("remote_addr", req.RemoteAddr),
+				slog.String("request_id", requestID),
+			)
+		})
+	}
+}
+
+// routePattern returns the path pattern of the route matched by the request, or an empty string.
+func routePattern(req *http.Request) string {
+	if route := routeFromRequest(req); route != nil {
+		return route.Info().Path
+	}
+	return ""
+}
+
+// CommonLog returns a Middleware writing a line to out for each request, in the Common Log Format used by web
+// servers, eg.
+//
+//	127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif HTTP/1.0" 200 2326
+func CommonLog(out io.Writer) Middleware {
+	return logFormat(out, false)
+}
+
+// CombinedLog returns a Middleware writing a line to out for each request, in the Combined Log Format, which is the
+// Common Log Format (see CommonLog) followed by the Referer and the User-Agent of the request.
+func CombinedLog(out io.Writer) Middleware {
+	return logFormat(out, true)
+}
+
+func logFormat(out io.Writer, combined bool) Middleware {
+	var mu sync.Mutex // Serializes the writes to out.
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			start := time.Now()
+			rw := NewResponseWriter(w)
+			next.ServeHTTP(rw, req)
+
+			host, _, err := net.SplitHostPort(req.RemoteAddr)
+			if err != nil {
+				host = req.RemoteAddr
+			}
+			user := "-"
+			if name, _, ok := req.BasicAuth(); ok && name != "" {
+				user = name
+			} else if req.URL.User != nil && req.URL.User.Username() != "" {
+				user = req.URL.User.Username()
+			}
+			uri := req.RequestURI
+			if uri == "" {
+				uri = req.URL.RequestURI()
+			}
+			status := rw.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			size := "-"
+			if rw.BytesWritten() > 0 {
+				size = fmt.Sprint(rw.BytesWritten())
+			}
+
+			line := fmt.Sprintf("%s - %s [%s] \"%s %s %s\" %d %s", host, escapeLogField(user),
+				start.Format("02/Jan/2006:15:04:05 -0700"), req.Method, escapeLogField(uri), req.Proto, status, size)
+			if combined {
+				line += fmt.Sprintf(" \"%s\" \"%s\"", logField(req.Referer()), logField(req.UserAgent()))
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			_, _ = io.WriteString(out, line+"\n")
+		})
+	}
+}
+
+// logField returns s escaped with escapeLogField, or "-" if it is empty.
+func logField(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return escapeLogField(s)
+}
+
+// escapeLogField escapes the quotes, backslashes and control characters of s, so that the fields of a log line can
+// be told apart.
+func escapeLogField(s string) string {
+	var b strings.Builder
+	for _, c := range []byte(s) {
+		switch {
+		case c == '"' || c == '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c < 0x20 || c == 0x7f:
+			fmt.Fprintf(&b, "\\x%02x", c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
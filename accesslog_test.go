@@ -0,0 +1,150 @@
+package minirouter
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestAccessLog(t *testing.T) {
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logs, nil))
+
+	r := New()
+	r.Use(AccessLog(logger), RequestIDMiddleware(RequestIDOptions{Generate: func() string { return "generated" }}))
+	r.GET("/users/:id", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("john"))
+	})
+	r.WithMiddleware(Recover).POST("/users", func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})
+	sub := New()
+	sub.GET("/items/:id", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("item"))
+	})
+	r.MountMini("/api", sub)
+	subWithGlobal := New()
+	subWithGlobal.Use(func(next http.Handler) http.Handler { return next })
+	subWithGlobal.GET("/items/:id", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("item"))
+	})
+	r.MountMini("/v2", subWithGlobal)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		level  string
+		route  string
+		status int
+		bytes  int
+	}{
+		{name: "Matched route", method: http.MethodGet, path: "/users/42", level: "INFO", route: "/users/:id", status: 200, bytes: 4},
+		{name: "Unmatched route", method: http.MethodGet, path: "/unknown", level: "INFO", route: "", status: 404, bytes: 19},
+		{name: "Server error", method: http.MethodPost, path: "/users", level: "ERROR", route: "/users", status: 500, bytes: 22},
+		{name: "Mounted sub-application", method: http.MethodGet, path: "/api/items/42", level: "INFO", route: "/api/items/:id", status: 200, bytes: 4},
+		{name: "Mounted sub-application with global middlewares", method: http.MethodGet, path: "/v2/items/42", level: "INFO", route: "/v2/items/:id", status: 200, bytes: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs.Reset()
+			defer log.SetOutput(log.Writer())
+			log.SetOutput(io.Discard)
+
+			r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(tt.method, tt.path, nil))
+
+			var record struct {
+				Level      string `json:"level"`
+				Msg        string `json:"msg"`
+				Method     string `json:"method"`
+				Route      string `json:"route"`
+				Status     int    `json:"status"`
+				Bytes      int    `json:"bytes"`
+				Latency    *int64 `json:"latency"`
+				RemoteAddr string `json:"remote_addr"`
+				RequestID  string `json:"request_id"`
+			}
+			assertNoError(t, json.Unmarshal(logs.Bytes(), &record))
+			if record.Level != tt.level || record.Msg != "request" || record.Method != tt.method || record.Route != tt.route ||
+				record.Status != tt.status || record.Bytes != tt.bytes || record.Latency == nil ||
+				record.RemoteAddr != "192.0.2.1:1234" || record.RequestID != "generated" {
+				t.Errorf("Wrong record %s", logs.String())
+			}
+		})
+	}
+}
+
+func TestCommonLog(t *testing.T) {
+	var logs bytes.Buffer
+
+	r := New()
+	r.Use(CommonLog(&logs))
+	combined := r.WithBasePath("/combined").WithMiddleware(CombinedLog(&logs))
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte("created"))
+	}
+	r.POST("/users", handler)
+	combined.POST("/users", handler)
+
+	const timestamp = `\[\d{2}/\w{3}/\d{4}:\d{2}:\d{2}:\d{2} [+-]\d{4}\]`
+	tests := []struct {
+		name    string
+		path    string
+		user    string
+		headers map[string]string
+		want    []string
+	}{
+		{
+			name: "Common", path: "/users?page=2", user: "frank",
+			want: []string{`192\.0\.2\.1 - frank ` + timestamp + ` "POST /users\?page=2 HTTP/1\.1" 201 7`},
+		}, {
+			name: "Not found", path: "/unknown",
+			want: []string{`192\.0\.2\.1 - - ` + timestamp + ` "POST /unknown HTTP/1\.1" 404 19`},
+		}, {
+			name: "Combined without Referer nor User-Agent", path: "/combined/users",
+			headers: map[string]string{"User-Agent": ""},
+			want: []string{
+				`192\.0\.2\.1 - - ` + timestamp + ` "POST /combined/users HTTP/1\.1" 201 7 "-" "-"`,
+				`192\.0\.2\.1 - - ` + timestamp + ` "POST /combined/users HTTP/1\.1" 201 7`,
+			},
+		}, {
+			name: "Combined", path: "/combined/users",
+			headers: map[string]string{"Referer": "https://example.com/", "User-Agent": `agent "007"`},
+			want: []string{
+				`192\.0\.2\.1 - - ` + timestamp + ` "POST /combined/users HTTP/1\.1" 201 7 "https://example\.com/" "agent \\"007\\""`,
+				`192\.0\.2\.1 - - ` + timestamp + ` "POST /combined/users HTTP/1\.1" 201 7`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs.Reset()
+			req := httptest.NewRequest(http.MethodPost, tt.path, nil)
+			if tt.user != "" {
+				req.SetBasicAuth(tt.user, "secret")
+			}
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+			r.ServeHTTP(httptest.NewRecorder(), req)
+
+			lines := strings.Split(strings.TrimSuffix(logs.String(), "\n"), "\n")
+			if len(lines) != len(tt.want) {
+				t.Fatalf("Wrong number of lines %q", lines)
+			}
+			for i, want := range tt.want {
+				if !regexp.MustCompile("^" + want + "$").MatchString(lines[i]) {
+					t.Errorf("Wrong line %q", lines[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package minirouter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// BindError is the error returned by Bind when a value of the request cannot be bound to the destination struct.
+type BindError struct {
+	// Source is where the value comes from: "path", "query", "header", "form" or "body".
+	Source string
+	// Name is the name of the value in its source (empty for the body).
+	Name  string
+	Value string
+	Err   error
+}
+
+func (e *BindError) Error() string {
+	if e.Source == "body" {
+		return fmt.Sprintf("invalid request body: %s", e.Err)
+	}
+	return fmt.Sprintf("invalid %s parameter '%s' ('%s'): %s", e.Source, e.Name, e.Value, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *BindError) Unwrap() error {
+	return e.Err
+}
+
+// StatusCode returns http.StatusBadRequest.
+func (e *BindError) StatusCode() int {
+	return http.StatusBadRequest
+}
+
+// bindSources lists the struct tags used by Bind, in the order they are applied.
+var bindSources = []string{"path", "query", "header", "form"}
+
+// Bind decodes the request into the struct pointed to by dst:
+//   - a JSON body (Content-Type application/json or */*+json) is decoded into dst with encoding/json,
+//   - fields tagged with `path:"<name>"` are set from the path parameters (see Params),
+//   - fields tagged with `query:"<name>"` are set from the query string,
+//   - fields tagged with `header:"<name>"` are set from the request headers,
+//   - fields tagged with `form:"<name>"` are set from the url-encoded or multipart form body.
+//
+// Values are converted like with BindPath; slice fields receive all the values of query, header and form parameters.
+// Missing path parameters are errors, while other missing values leave their field untouched.
+// It returns a *BindError, which is written as a 400 response by the error handlers, if a value cannot be bound.
+// Once bound, dst is validated with ValidateStruct, which may return a *ValidationError.
+func Bind(req *http.Request, dst interface{}) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return errors.New("minirouter: Bind expects a pointer to a struct")
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		if err := json.NewDecoder(req.Body).Decode(dst); err != nil && err != io.EOF {
+			return &BindError{Source: "body", Err: err}
+		}
+	case mediaType == "multipart/form-data":
+		if err := req.ParseMultipartForm(32 << 20); err != nil {
+			return &BindError{Source: "body", Err: err}
+		}
+	case mediaType == "application/x-www-form-urlencoded":
+		if err := req.ParseForm(); err != nil {
+			return &BindError{Source: "body", Err: err}
+		}
+	}
+
+	if err := bindFields(req, v.Elem()); err != nil {
+		return err
+	}
+	return ValidateStruct(dst)
+}
+
+func bindFields(req *http.Request, v reflect.Value) error {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			if err := bindFields(req, v.Field(i)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		for _, source := range bindSources {
+			name, ok := field.Tag.Lookup(source)
+			if !ok {
+				continue
+			}
+			values := requestValues(req, source, name)
+			if len(values) == 0 {
+				if source == "path" {
+					return &BindError{Source: source, Name: name, Err: errors.New("missing value")}
+				}
+				continue
+			}
+			if err := setValues(v.Field(i), values); err != nil {
+				return &BindError{Source: source, Name: name, Value: strings.Join(values, ","), Err: err}
+			}
+		}
+	}
+	return nil
+}
+
+// requestValues returns the values of the request named name in the given source.
+func requestValues(req *http.Request, source, name string) []string {
+	switch source {
+	case "path":
+		if value := Params(req).ByName(name); value != "" {
+			return []string{value}
+		}
+	case "query":
+		return req.URL.Query()[name]
+	case "header":
+		return req.Header.Values(name)
+	case "form":
+		return req.PostForm[name]
+	}
+	return nil
+}
+
+// setValues sets v from values: all of them for slices (except []byte), the first one otherwise.
+func setValues(v reflect.Value, values []string) error {
+	switch {
+	case v.Kind() != reflect.Slice || reflect.PtrTo(v.Type()).Implements(textUnmarshalerType):
+		return setValue(v, values[0])
+	case v.Type().Elem().Kind() == reflect.Uint8:
+		v.SetBytes([]byte(values[0]))
+		return nil
+	}
+
+	slice := reflect.MakeSlice(v.Type(), len(values), len(values))
+	for i, value := range values {
+		if err := setValue(slice.Index(i), value); err != nil {
+			return err
+		}
+	}
+	v.Set(slice)
+	return nil
+}
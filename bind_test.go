@@ -0,0 +1,115 @@
+package minirouter
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+type bindTarget struct {
+	ID      int      `path:"id"`
+	Page    int      `query:"page"`
+	Tags    []string `query:"tag"`
+	Token   string   `header:"X-Token"`
+	Comment string   `form:"comment"`
+	Name    string   `json:"name"`
+	Age     int      `json:"age"`
+}
+
+func TestBind(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		query       string
+		want        bindTarget
+		wantSource  string
+	}{
+		{
+			name:        "JSON body",
+			contentType: "application/json; charset=utf-8",
+			body:        `{"name":"john","age":42}`,
+			query:       "page=2&tag=a&tag=b",
+			want:        bindTarget{ID: 7, Page: 2, Tags: []string{"a", "b"}, Token: "secret", Name: "john", Age: 42},
+		}, {
+			name:        "Form body",
+			contentType: "application/x-www-form-urlencoded",
+			body:        url.Values{"comment": {"hello"}}.Encode(),
+			want:        bindTarget{ID: 7, Token: "secret", Comment: "hello"},
+		}, {
+			name:  "No body",
+			query: "page=3",
+			want:  bindTarget{ID: 7, Page: 3, Token: "secret"},
+		}, {
+			name:        "Malformed JSON body",
+			contentType: "application/json",
+			body:        `{"name":`,
+			wantSource:  "body",
+		}, {
+			name:        "Wrong JSON type",
+			contentType: "application/json",
+			body:        `{"age":"old"}`,
+			wantSource:  "body",
+		}, {
+			name:       "Invalid query value",
+			query:      "page=two",
+			wantSource: "query",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := requestWithParams("id", "7")
+			req.URL.RawQuery = tt.query
+			req.Header.Set("X-Token", "secret")
+			if tt.body != "" {
+				req.Method = http.MethodPost
+				req.Body = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body)).Body
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+
+			var got bindTarget
+			err := Bind(req, &got)
+			if tt.wantSource != "" {
+				var bindErr *BindError
+				if !errors.As(err, &bindErr) || bindErr.Source != tt.wantSource {
+					t.Fatalf("Expected a *BindError from %s, got %v", tt.wantSource, err)
+				}
+				if StatusCode(err) != http.StatusBadRequest {
+					t.Errorf("Wrong status code %d", StatusCode(err))
+				}
+				return
+			}
+			assertNoError(t, err)
+			if got.ID != tt.want.ID || got.Page != tt.want.Page || strings.Join(got.Tags, ",") != strings.Join(tt.want.Tags, ",") ||
+				got.Token != tt.want.Token || got.Comment != tt.want.Comment || got.Name != tt.want.Name || got.Age != tt.want.Age {
+				t.Errorf("Wrong bound struct. Expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestBind_errorResponse(t *testing.T) {
+	r := New()
+	r.POST("/users/:id", r.E(func(w http.ResponseWriter, r *http.Request) error {
+		var target bindTarget
+		if err := Bind(r, &target); err != nil {
+			return err
+		}
+		_, err := w.Write([]byte(target.Name))
+		return err
+	}))
+
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	res, err := http.Post(srv.URL+"/users/42", "application/json", strings.NewReader(`{"name":"john"}`))
+	assertNoError(t, err)
+	assertResponse(t, res, 200, "", "", "john")
+
+	res, err = http.Post(srv.URL+"/users/john", "application/json", strings.NewReader(`{"name":"john"}`))
+	assertNoError(t, err)
+	assertResponse(t, res, http.StatusBadRequest, "", "", `invalid path parameter 'id' ('john'): strconv.ParseInt: parsing "john": invalid syntax`)
+}
@@ -0,0 +1,105 @@
+package minirouter
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// RouteError is an error that occurred while registering a route, typically a conflict with another route.
+type RouteError struct {
+	Method string
+	// Path is the full path of the route, including the base-path of the group it was registered on.
+	Path string
+	// Source is the location (file:line) of the code that registered the route.
+	Source string
+	// Reason explains why the route could not be registered.
+	Reason string
+	// Existing is the previously registered route this one conflicts with, if it could be identified.
+	Existing *RouteInfo
+}
+
+func (e *RouteError) Error() string {
+	msg := fmt.Sprintf("%s %s (%s): %s", e.Method, e.Path, e.Source, e.Reason)
+	if e.Existing != nil {
+		msg += fmt.Sprintf(" [conflicts with %s %s (%s)]", e.Existing.Method, e.Existing.Path, e.Existing.Source)
+	}
+	return msg
+}
+
+// RouteErrors is a list of RouteError, as returned by Validate.
+type RouteErrors []*RouteError
+
+func (errs RouteErrors) Error() string {
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("minirouter: %d invalid route(s):\n\t%s", len(errs), strings.Join(msgs, "\n\t"))
+}
+
+// CollectConflicts switches m, and all the Mini sharing its router, to checked registration: instead of panicking,
+// the registration of a route that conflicts with a previously registered route is skipped and the conflict is
+// collected, so that all the conflicts can be reported at once by Validate.
+func (m *Mini) CollectConflicts() {
+	m.routes.mu.Lock()
+	defer m.routes.mu.Unlock()
+	m.routes.collectConflicts = true
+}
+
+// Validate returns the conflicts collected since CollectConflicts was called, as RouteErrors, or nil if there is none.
+func (m *Mini) Validate() error {
+	m.routes.mu.RLock()
+	defer m.routes.mu.RUnlock()
+	if len(m.routes.conflicts) == 0 {
+		return nil
+	}
+	return append(RouteErrors(nil), m.routes.conflicts...)
+}
+
+// register registers handler for the route described by info. If the route cannot be registered, a RouteError is
+// either collected or panicked, depending on whether CollectConflicts was called. It returns whether the route was
+// registered. Automatic HEAD routes (see WithAutoHEAD) are registered with auto set to true.
+func (m *Mini) register(info RouteInfo, handler http.Handler, auto bool) bool {
+	m.routes.mu.Lock()
+	defer m.routes.mu.Unlock()
+
+	reason, existing := m.insert(info, handler, auto)
+	if reason == "" {
+		return true
+	}
+
+	err := &RouteError{
+		Method:   info.Method,
+		Path:     info.Path,
+		Source:   info.Source,
+		Reason:   reason,
+		Existing: existing,
+	}
+	if !m.routes.collectConflicts {
+		panic(err)
+	}
+	m.routes.conflicts = append(m.routes.conflicts, err)
+	return false
+}
+
+// pkgPath is the import path of this package.
+var pkgPath = reflect.TypeOf(Mini{}).PkgPath()
+
+// callerSource returns the location (file:line) of the first caller outside of this package.
+func callerSource() string {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, pkgPath+".") || strings.HasSuffix(frame.File, "_test.go") {
+			return fmt.Sprintf("%s:%d", frame.File, frame.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
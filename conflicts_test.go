@@ -0,0 +1,82 @@
+package minirouter
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestMini_Handle_conflict(t *testing.T) {
+	noop := func(w http.ResponseWriter, r *http.Request) {}
+
+	r := New()
+	r.GET("/users/:id", noop)
+
+	defer func() {
+		err, ok := recover().(*RouteError)
+		if !ok {
+			t.Fatalf("Expected a *RouteError panic, got %v", err)
+		}
+		if err.Path != "/users/:name" || !strings.Contains(err.Source, "conflicts_test.go:") {
+			t.Errorf("Wrong route error %+v", err)
+		}
+	}()
+	r.GET("/users/:name", noop)
+}
+
+func TestMini_Validate(t *testing.T) {
+	noop := func(w http.ResponseWriter, r *http.Request) {}
+
+	r := New()
+	r.CollectConflicts()
+	r.GET("/users/:id", noop)
+	g := r.WithBasePath("/users")
+	g.GET("/:name", noop)
+	g.GET("/:id", noop)
+	g.POST("/:id", noop)
+	r.POST("/users/:id", noop)
+	r.GET("/files/*filepath/more", noop)
+
+	err := r.Validate()
+	var errs RouteErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Expected RouteErrors, got %v", err)
+	}
+	if len(errs) != 4 {
+		t.Fatalf("Expected 4 route errors, got %d: %v", len(errs), errs)
+	}
+
+	tests := []struct {
+		method   string
+		path     string
+		existing string
+	}{
+		{method: http.MethodGet, path: "/users/:name", existing: "/users/:id"},
+		{method: http.MethodGet, path: "/users/:id", existing: "/users/:id"},
+		{method: http.MethodPost, path: "/users/:id", existing: "/users/:id"},
+		{method: http.MethodGet, path: "/files/*filepath/more"},
+	}
+	for i, tt := range tests {
+		err := errs[i]
+		if err.Method != tt.method || err.Path != tt.path {
+			t.Errorf("Wrong route error #%d: %v", i, err)
+		}
+		if !strings.Contains(err.Source, "conflicts_test.go:") {
+			t.Errorf("Wrong source for route error #%d: %s", i, err.Source)
+		}
+		if tt.existing == "" {
+			if err.Existing != nil {
+				t.Errorf("Expected no existing route for route error #%d, got %v", i, err.Existing)
+			}
+			continue
+		}
+		if err.Existing == nil || err.Existing.Method != tt.method || err.Existing.Path != tt.existing {
+			t.Errorf("Wrong existing route for route error #%d: %v", i, err.Existing)
+		}
+	}
+
+	if routes := r.Routes(); len(routes) != 2 {
+		t.Errorf("Expected only valid routes to be registered, got %v", routes)
+	}
+}
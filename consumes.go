@@ -0,0 +1,102 @@
+package minirouter
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// UnsupportedMediaTypeError is the error written when the Content-Type of a request is not one of the media types
+// consumed by its route (see Route.Consumes).
+type UnsupportedMediaTypeError struct {
+	ContentType string
+	// Allowed lists the media types consumed by the route.
+	Allowed []string
+}
+
+func (e *UnsupportedMediaTypeError) Error() string {
+	if e.ContentType == "" {
+		return fmt.Sprintf("missing Content-Type, expected one of %s", strings.Join(e.Allowed, ", "))
+	}
+	return fmt.Sprintf("unsupported Content-Type '%s', expected one of %s", e.ContentType, strings.Join(e.Allowed, ", "))
+}
+
+// StatusCode returns http.StatusUnsupportedMediaType.
+func (e *UnsupportedMediaTypeError) StatusCode() int {
+	return http.StatusUnsupportedMediaType
+}
+
+// Problem converts the error into a Problem, listing the allowed media types in its "allowed" extension member.
+func (e *UnsupportedMediaTypeError) Problem() *Problem {
+	p := NewProblem(http.StatusUnsupportedMediaType, e.Error())
+	p.Extensions = map[string]interface{}{"allowed": e.Allowed}
+	return p
+}
+
+// MaxJSONBodySize is the maximum size, in bytes, of the JSON bodies of the requests to the routes consuming JSON (see
+// Route.Consumes). Since these bodies are read entirely to be checked before the handler runs, larger bodies are
+// rejected with 413 Request Entity Too Large. It must only be modified before requests are served.
+var MaxJSONBodySize int64 = 10 << 20
+
+// checkContentType returns a handler rejecting the requests whose body does not have one of the media types consumed
+// by the route they matched, before serving them to handler. JSON bodies are also rejected if they are malformed.
+// It wraps the route's handler inside the middlewares, so that they also see the rejected requests.
+func checkContentType(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var consumes []string
+		if route := routeFromRequest(req); route != nil {
+			consumes = route.Info().Doc.Consumes
+		}
+		if len(consumes) == 0 || req.Body == nil || req.Body == http.NoBody || req.ContentLength == 0 {
+			handler.ServeHTTP(w, req)
+			return
+		}
+
+		mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+		if !consumesMediaType(consumes, mediaType) {
+			w.Header().Set("Accept", strings.Join(consumes, ", "))
+			handleError(w, req, &UnsupportedMediaTypeError{ContentType: req.Header.Get("Content-Type"), Allowed: consumes})
+			return
+		}
+
+		if isJSON(mediaType) {
+			body, err := io.ReadAll(http.MaxBytesReader(w, req.Body, MaxJSONBodySize))
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				handleError(w, req, NewHTTPError(http.StatusRequestEntityTooLarge, err))
+				return
+			}
+			if err != nil {
+				handleError(w, req, &BindError{Source: "body", Err: err})
+				return
+			}
+			var raw json.RawMessage
+			if err := json.Unmarshal(body, &raw); err != nil {
+				handleError(w, req, &BindError{Source: "body", Err: err})
+				return
+			}
+			req.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		handler.ServeHTTP(w, req)
+	})
+}
+
+// consumesMediaType reports whether mediaType is one of consumes, which may contain ranges such as "text/*".
+func consumesMediaType(consumes []string, mediaType string) bool {
+	if mediaType == "" {
+		return false
+	}
+	for _, consumed := range consumes {
+		r := mediaRange{q: 1}
+		r.typ, r.subtype, _ = strings.Cut(consumed, "/")
+		if r.specificity(mediaType) >= 0 {
+			return true
+		}
+	}
+	return false
+}
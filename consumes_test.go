@@ -0,0 +1,107 @@
+package minirouter
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRoute_Consumes(t *testing.T) {
+	echo := func(w http.ResponseWriter, req *http.Request) {
+		b, _ := io.ReadAll(req.Body)
+		_, _ = w.Write(b)
+	}
+
+	defer func(size int64) { MaxJSONBodySize = size }(MaxJSONBodySize)
+	MaxJSONBodySize = 100
+
+	r := New()
+	r.POST("/json", echo).Consumes("application/json")
+	r.POST("/text", echo).Consumes("text/*", "application/x-www-form-urlencoded")
+	r.POST("/any", echo)
+	r.WithErrorHandler(ProblemErrorHandler).POST("/problem", echo).Consumes("application/json")
+
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	tests := []struct {
+		name        string
+		path        string
+		contentType string
+		body        string
+		status      int
+		accept      string
+		response    string
+	}{
+		{
+			name: "JSON", path: "/json", contentType: "application/json; charset=utf-8", body: `{"name":"john"}`,
+			status: 200, response: `{"name":"john"}`,
+		}, {
+			name: "No body", path: "/json", status: 200, response: "",
+		}, {
+			name: "Form instead of JSON", path: "/json", contentType: "application/x-www-form-urlencoded", body: "name=john",
+			status: 415, accept: "application/json",
+			response: "unsupported Content-Type 'application/x-www-form-urlencoded', expected one of application/json",
+		}, {
+			name: "Missing Content-Type", path: "/json", body: `{"name":"john"}`,
+			status: 415, accept: "application/json",
+			response: "missing Content-Type, expected one of application/json",
+		}, {
+			name: "Malformed JSON", path: "/json", contentType: "application/json", body: `{"name":`,
+			status: 400, response: "invalid request body: unexpected end of JSON input",
+		}, {
+			name: "Too large JSON", path: "/json", contentType: "application/json", body: `["` + strings.Repeat("a", 100) + `"]`,
+			status: 413, response: "http: request body too large",
+		}, {
+			name: "Range", path: "/text", contentType: "text/csv", body: "john,doe",
+			status: 200, response: "john,doe",
+		}, {
+			name: "Range mismatch", path: "/text", contentType: "application/json", body: `{}`,
+			status: 415, accept: "text/*, application/x-www-form-urlencoded",
+			response: "unsupported Content-Type 'application/json', expected one of text/*, application/x-www-form-urlencoded",
+		}, {
+			name: "No declaration", path: "/any", contentType: "image/png", body: "png",
+			status: 200, response: "png",
+		}, {
+			name: "Problem", path: "/problem", contentType: "text/plain", body: "john",
+			status: 415, accept: "application/json",
+			response: `{"allowed":["application/json"],"detail":"unsupported Content-Type 'text/plain', expected one of application/json","status":415,"title":"Unsupported Media Type"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body io.Reader
+			if tt.body != "" {
+				body = strings.NewReader(tt.body)
+			}
+			req, err := http.NewRequest(http.MethodPost, srv.URL+tt.path, body)
+			assertNoError(t, err)
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			res, err := http.DefaultClient.Do(req)
+			assertNoError(t, err)
+			if accept := res.Header.Get("Accept"); accept != tt.accept {
+				t.Errorf("Wrong Accept header %q", accept)
+			}
+			assertResponse(t, res, tt.status, "", "", tt.response)
+		})
+	}
+
+	t.Run("Middlewares", func(t *testing.T) {
+		g := r.WithMiddleware(RequestIDMiddleware(RequestIDOptions{Generate: func() string { return "generated" }}))
+		g.POST("/grouped", echo).Consumes("application/json")
+
+		req, err := http.NewRequest(http.MethodPost, srv.URL+"/grouped", strings.NewReader("name=john"))
+		assertNoError(t, err)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		res, err := http.DefaultClient.Do(req)
+		assertNoError(t, err)
+		if id := res.Header.Get(RequestIDHeader); id != "generated" {
+			t.Errorf("Expected the rejected request to go through the route's middlewares, got request ID %q", id)
+		}
+		assertResponse(t, res, 415, "", "", "unsupported Content-Type 'application/x-www-form-urlencoded', expected one of application/json")
+	})
+}
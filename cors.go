@@ -0,0 +1,158 @@
+package minirouter
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CORSOptions configures the Cross-Origin Resource Sharing of a group of routes (see WithCORS).
+type CORSOptions struct {
+	// AllowedOrigins lists the origins allowed to make cross-origin requests, eg. "https://example.com".
+	// "*" allows any origin, and a wildcard can be used within an origin, eg. "https://*.example.com".
+	AllowedOrigins []string
+	// AllowedHeaders lists the request headers allowed in cross-origin requests. When empty, the headers requested by
+	// preflight requests are allowed.
+	AllowedHeaders []string
+	// ExposedHeaders lists the response headers that browsers let scripts read.
+	ExposedHeaders []string
+	// AllowCredentials allows cross-origin requests to include credentials (cookies, authorization headers...).
+	// It cannot be combined with the "*" origin, which would let any website make requests on behalf of the users:
+	// WithCORS panics if AllowedOrigins contains "*" while AllowCredentials is true.
+	AllowCredentials bool
+	// MaxAge is how long the result of a preflight request can be cached. It is not sent if zero.
+	MaxAge time.Duration
+}
+
+// WithCORS returns a copy of parent whose routes answer cross-origin requests according to options.
+//
+// Preflight requests (OPTIONS requests with Origin and Access-Control-Request-Method headers) to the routes registered
+// on the returned Mini and its copies are answered before the route lookup, without running the group's middlewares,
+// so that middlewares rejecting unauthenticated requests do not reject preflights. The allowed methods are the methods
+// of these routes matching the requested path. Preflights from a disallowed origin, or for a method none of them
+// handles, are rejected with 403. Preflights to the paths of other routes are served as usual.
+// When groups are nested, the options of the innermost group apply.
+//
+// Other cross-origin requests get the CORS headers from a middleware added to the group. Unless any origin is allowed
+// (AllowedOrigins is exactly ["*"]), all the responses of the group vary with the Origin header, which they state
+// with a Vary header so that shared caches do not serve them to other origins.
+func (m *Mini) WithCORS(options CORSOptions) *Mini {
+	if options.AllowCredentials && slices.Contains(options.AllowedOrigins, "*") {
+		panic(`minirouter: CORSOptions.AllowCredentials cannot be used with the "*" origin`)
+	}
+	policy := &corsPolicy{options: options}
+	newMini := m.WithMiddleware(policy.middleware)
+	newMini.cors = policy
+	return newMini
+}
+
+// corsPolicy applies CORSOptions.
+type corsPolicy struct {
+	options CORSOptions
+}
+
+// allowOrigin reports whether origin is allowed.
+func (p *corsPolicy) allowOrigin(origin string) bool {
+	origin = strings.ToLower(origin)
+	for _, allowed := range p.options.AllowedOrigins {
+		allowed = strings.ToLower(allowed)
+		if allowed == "*" || allowed == origin {
+			return true
+		}
+		if prefix, suffix, ok := strings.Cut(allowed, "*"); ok &&
+			len(origin) > len(prefix)+len(suffix) && strings.HasPrefix(origin, prefix) && strings.HasSuffix(origin, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowAny reports whether any origin is allowed, in which case the responses do not depend on the origin.
+func (p *corsPolicy) allowAny() bool {
+	return len(p.options.AllowedOrigins) == 1 && p.options.AllowedOrigins[0] == "*"
+}
+
+// setOriginHeaders sets the headers common to preflight and actual responses for the allowed origin.
+func (p *corsPolicy) setOriginHeaders(h http.Header, origin string) {
+	if p.allowAny() {
+		h.Set("Access-Control-Allow-Origin", "*")
+	} else {
+		h.Set("Access-Control-Allow-Origin", origin)
+	}
+	if p.options.AllowCredentials {
+		h.Set("Access-Control-Allow-Credentials", "true")
+	}
+}
+
+// middleware adds the CORS headers to the responses of actual cross-origin requests.
+func (p *corsPolicy) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if !p.allowAny() {
+			w.Header().Add("Vary", "Origin")
+		}
+		if origin := req.Header.Get("Origin"); origin != "" && p.allowOrigin(origin) {
+			p.setOriginHeaders(w.Header(), origin)
+			if len(p.options.ExposedHeaders) > 0 {
+				w.Header().Set("Access-Control-Expose-Headers", strings.Join(p.options.ExposedHeaders, ", "))
+			}
+		}
+		next.ServeHTTP(w, req)
+	})
+}
+
+// preflight returns the CORS policy applying to the preflight request, along with the methods allowed by the policy
+// for the requested path, or nil if no route with a CORS policy matches the path. The policy of the route handling the
+// requested method wins.
+func (reg *registry) preflight(req *http.Request) (*corsPolicy, []string) {
+	method := req.Header.Get("Access-Control-Request-Method")
+	var policy *corsPolicy
+	reg.mu.RLock()
+	for _, entry := range reg.entries {
+		if entry.cors == nil || (policy != nil && entry.info.Method != method) {
+			continue
+		}
+		if _, ok := entry.pattern.match(req.URL.Path); ok {
+			policy = entry.cors
+		}
+	}
+	reg.mu.RUnlock()
+
+	if policy == nil {
+		return nil, nil
+	}
+	return policy, reg.allowedMethods(req.URL.Path, func(entry *routeEntry) bool { return entry.cors == policy })
+}
+
+// servePreflight answers a preflight request to a path whose routes allow methods.
+func (p *corsPolicy) servePreflight(w http.ResponseWriter, req *http.Request, methods []string) {
+	origin := req.Header.Get("Origin")
+	if !p.allowAny() {
+		w.Header().Add("Vary", "Origin")
+	}
+	w.Header().Add("Vary", "Access-Control-Request-Method")
+	w.Header().Add("Vary", "Access-Control-Request-Headers")
+	if !p.allowOrigin(origin) || !containsMethod(methods, req.Header.Get("Access-Control-Request-Method")) {
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+
+	p.setOriginHeaders(w.Header(), origin)
+	w.Header().Set("Access-Control-Allow-Methods", strings.Join(methods, ", "))
+	if len(p.options.AllowedHeaders) > 0 {
+		w.Header().Set("Access-Control-Allow-Headers", strings.Join(p.options.AllowedHeaders, ", "))
+	} else if headers := req.Header.Get("Access-Control-Request-Headers"); headers != "" {
+		w.Header().Set("Access-Control-Allow-Headers", headers)
+	}
+	if p.options.MaxAge > 0 {
+		w.Header().Set("Access-Control-Max-Age", strconv.Itoa(int(p.options.MaxAge.Seconds())))
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// isPreflight reports whether req is a CORS preflight request.
+func isPreflight(req *http.Request) bool {
+	return req.Method == http.MethodOptions && req.Header.Get("Origin") != "" &&
+		req.Header.Get("Access-Control-Request-Method") != ""
+}
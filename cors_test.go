@@ -0,0 +1,142 @@
+package minirouter
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestMini_WithCORS(t *testing.T) {
+	ok := func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("OK"))
+	}
+	denyAll := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		})
+	}
+
+	r := New()
+	r.WithCORS(CORSOptions{AllowedOrigins: []string{"*"}}).GET("/open", ok)
+	r.GET("/private", ok)
+	api := r.WithBasePath("/api").WithCORS(CORSOptions{
+		AllowedOrigins: []string{"https://*.example.com"},
+		AllowedHeaders: []string{"Content-Type", "Authorization"},
+		ExposedHeaders: []string{"X-Total"},
+		MaxAge:         10 * time.Minute,
+	})
+	api.GET("/users/:id", ok)
+	api.PUT("/users/:id", ok)
+	api.WithMiddleware(denyAll).DELETE("/users/:id", ok)
+	public := r.WithBasePath("/public").WithCORS(CORSOptions{AllowedOrigins: []string{"https://example.org"}, AllowCredentials: true})
+	public.POST("/events", ok)
+
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	tests := []struct {
+		name          string
+		method        string
+		path          string
+		origin        string
+		requestMethod string
+		status        int
+		headers       map[string]string
+	}{
+		{
+			name: "Preflight", method: http.MethodOptions, path: "/api/users/42",
+			origin: "https://app.example.com", requestMethod: http.MethodDelete, status: 204,
+			headers: map[string]string{
+				"Access-Control-Allow-Origin":  "https://app.example.com",
+				"Access-Control-Allow-Methods": "GET, PUT, DELETE",
+				"Access-Control-Allow-Headers": "Content-Type, Authorization",
+				"Access-Control-Max-Age":       "600",
+			},
+		}, {
+			name: "Preflight from a disallowed origin", method: http.MethodOptions, path: "/api/users/42",
+			origin: "https://example.org", requestMethod: http.MethodGet, status: 403,
+			headers: map[string]string{"Access-Control-Allow-Origin": "", "Access-Control-Allow-Methods": ""},
+		}, {
+			name: "Preflight for an unregistered method", method: http.MethodOptions, path: "/api/users/42",
+			origin: "https://app.example.com", requestMethod: http.MethodPost, status: 403,
+			headers: map[string]string{"Access-Control-Allow-Origin": ""},
+		}, {
+			name: "Preflight with credentials", method: http.MethodOptions, path: "/public/events",
+			origin: "https://example.org", requestMethod: http.MethodPost, status: 204,
+			headers: map[string]string{
+				"Access-Control-Allow-Origin":      "https://example.org",
+				"Access-Control-Allow-Credentials": "true",
+				"Access-Control-Allow-Methods":     "POST",
+				"Access-Control-Allow-Headers":     "X-Custom",
+			},
+		}, {
+			name: "Preflight outside of CORS groups", method: http.MethodOptions, path: "/private",
+			origin: "https://app.example.com", requestMethod: http.MethodGet, status: 200,
+			headers: map[string]string{"Access-Control-Allow-Origin": "", "Allow": "GET, OPTIONS"},
+		}, {
+			name: "Preflight to a root group with CORS", method: http.MethodOptions, path: "/open",
+			origin: "https://example.org", requestMethod: http.MethodGet, status: 204,
+			headers: map[string]string{
+				"Access-Control-Allow-Origin":  "*",
+				"Access-Control-Allow-Methods": "GET",
+				"Vary":                         "Access-Control-Request-Method",
+			},
+		}, {
+			name: "Actual request to a group allowing any origin", method: http.MethodGet, path: "/open",
+			origin: "https://example.org", status: 200,
+			headers: map[string]string{"Access-Control-Allow-Origin": "*", "Vary": ""},
+		}, {
+			name: "Actual request", method: http.MethodGet, path: "/api/users/42",
+			origin: "https://app.example.com", status: 200,
+			headers: map[string]string{
+				"Access-Control-Allow-Origin":   "https://app.example.com",
+				"Access-Control-Expose-Headers": "X-Total",
+			},
+		}, {
+			name: "Actual request from a disallowed origin", method: http.MethodGet, path: "/api/users/42",
+			origin: "https://example.org", status: 200,
+			headers: map[string]string{"Access-Control-Allow-Origin": "", "Vary": "Origin"},
+		}, {
+			name: "Actual request without origin", method: http.MethodGet, path: "/api/users/42", status: 200,
+			headers: map[string]string{"Access-Control-Allow-Origin": "", "Vary": "Origin"},
+		}, {
+			name: "Actual request rejected by a middleware", method: http.MethodDelete, path: "/api/users/42",
+			origin: "https://app.example.com", status: 401,
+			headers: map[string]string{"Access-Control-Allow-Origin": "https://app.example.com"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, srv.URL+tt.path, nil)
+			assertNoError(t, err)
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			if tt.requestMethod != "" {
+				req.Header.Set("Access-Control-Request-Method", tt.requestMethod)
+				req.Header.Set("Access-Control-Request-Headers", "X-Custom")
+			}
+			res, err := http.DefaultClient.Do(req)
+			assertNoError(t, err)
+			res.Body.Close()
+			if res.StatusCode != tt.status {
+				t.Errorf("Wrong status code. Expected %d, got %d", tt.status, res.StatusCode)
+			}
+			for name, value := range tt.headers {
+				if got := res.Header.Get(name); got != value {
+					t.Errorf("Wrong %s header. Expected %q, got %q", name, value, got)
+				}
+			}
+		})
+	}
+
+	t.Run("Credentials with any origin", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected WithCORS to panic when credentials are allowed for any origin")
+			}
+		}()
+		New().WithCORS(CORSOptions{AllowedOrigins: []string{"*"}, AllowCredentials: true})
+	})
+}
@@ -0,0 +1,100 @@
+package minirouter
+
+import (
+	"errors"
+	"net/http"
+)
+
+// HandlerFuncE is an http.HandlerFunc that may return an error. Returned errors are written by the ErrorHandler of the
+// Mini the handler was registered on.
+type HandlerFuncE func(w http.ResponseWriter, r *http.Request) error
+
+// ErrorHandler writes the response corresponding to an error returned by a HandlerFuncE.
+type ErrorHandler func(w http.ResponseWriter, r *http.Request, err error)
+
+// HTTPError is an error associated with an HTTP status code.
+type HTTPError struct {
+	Code int
+	Err  error
+}
+
+// NewHTTPError returns an error that is written with the given status code by DefaultErrorHandler.
+func NewHTTPError(code int, err error) *HTTPError {
+	return &HTTPError{Code: code, Err: err}
+}
+
+func (e *HTTPError) Error() string {
+	if e.Err == nil {
+		return http.StatusText(e.Code)
+	}
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *HTTPError) Unwrap() error {
+	return e.Err
+}
+
+// StatusCode returns the HTTP status code of the error.
+func (e *HTTPError) StatusCode() int {
+	return e.Code
+}
+
+// StatusCode returns the HTTP status code associated with err, that is the status code of the first error in err's
+// chain having a StatusCode() int method. It defaults to http.StatusInternalServerError.
+func StatusCode(err error) int {
+	var sc interface{ StatusCode() int }
+	if errors.As(err, &sc) {
+		return sc.StatusCode()
+	}
+	return http.StatusInternalServerError
+}
+
+// DefaultErrorHandler is the ErrorHandler used when none has been set with WithErrorHandler.
+// It writes the status code given by StatusCode along with the error's message. The message of errors without status
+// code is not written to avoid leaking internal details; the status text is written instead.
+func DefaultErrorHandler(w http.ResponseWriter, r *http.Request, err error) {
+	var sc interface{ StatusCode() int }
+	if errors.As(err, &sc) {
+		http.Error(w, err.Error(), sc.StatusCode())
+		return
+	}
+	http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+}
+
+// WithErrorHandler returns a copy of parent with a new ErrorHandler, which is used for the errors returned by the
+// HandlerFuncE registered on it and on its own copies.
+func (m *Mini) WithErrorHandler(handler ErrorHandler) *Mini {
+	newMini := m.WithBasePath("")
+	newMini.errorHandler = handler
+	return newMini
+}
+
+// E adapts a HandlerFuncE into an http.HandlerFunc whose errors are written by m's ErrorHandler, so that it can be
+// registered with GET, POST, etc.
+func (m *Mini) E(handler HandlerFuncE) http.HandlerFunc {
+	errorHandler := m.errorHandler
+	if errorHandler == nil {
+		errorHandler = DefaultErrorHandler
+	}
+	return func(w http.ResponseWriter, req *http.Request) {
+		if err := handler(w, req); err != nil {
+			errorHandler(w, req, err)
+		}
+	}
+}
+
+// handleError writes err with the ErrorHandler of the route matched by the request, or with DefaultErrorHandler if
+// the route has none.
+func handleError(w http.ResponseWriter, req *http.Request, err error) {
+	if route := routeFromRequest(req); route != nil && route.errorHandler != nil {
+		route.errorHandler(w, req, err)
+		return
+	}
+	DefaultErrorHandler(w, req, err)
+}
+
+// HandleFuncE registers a HandlerFuncE for the given method and path.
+func (m *Mini) HandleFuncE(method, path string, handler HandlerFuncE, middleware ...Middleware) *Route {
+	return m.Handle(method, path, m.E(handler), middleware...)
+}
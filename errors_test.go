@@ -0,0 +1,83 @@
+package minirouter
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestStatusCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "Plain error", err: errors.New("boom"), want: http.StatusInternalServerError},
+		{name: "HTTP error", err: NewHTTPError(http.StatusNotFound, errors.New("no such user")), want: http.StatusNotFound},
+		{name: "Wrapped HTTP error", err: fmt.Errorf("wrapped: %w", NewHTTPError(http.StatusConflict, nil)), want: http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StatusCode(tt.err); got != tt.want {
+				t.Errorf("StatusCode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMini_HandleFuncE(t *testing.T) {
+	t.Run("Default error handler", func(t *testing.T) {
+		r := New()
+		r.HandleFuncE(http.MethodGet, "/ok", func(w http.ResponseWriter, r *http.Request) error {
+			_, err := w.Write([]byte("OK"))
+			return err
+		})
+		r.HandleFuncE(http.MethodGet, "/not-found", func(w http.ResponseWriter, r *http.Request) error {
+			return NewHTTPError(http.StatusNotFound, errors.New("no such user"))
+		})
+		r.GET("/internal", r.E(func(w http.ResponseWriter, r *http.Request) error {
+			return errors.New("secret database error")
+		}))
+
+		srv := httptest.NewServer(r)
+		defer srv.Close()
+
+		res, err := http.Get(srv.URL + "/ok")
+		assertNoError(t, err)
+		assertResponse(t, res, 200, "", "", "OK")
+
+		res, err = http.Get(srv.URL + "/not-found")
+		assertNoError(t, err)
+		assertResponse(t, res, http.StatusNotFound, "", "", "no such user")
+
+		res, err = http.Get(srv.URL + "/internal")
+		assertNoError(t, err)
+		assertResponse(t, res, http.StatusInternalServerError, "", "", "Internal Server Error")
+	})
+
+	t.Run("Error handler inherited by copies", func(t *testing.T) {
+		r := New().WithErrorHandler(func(w http.ResponseWriter, r *http.Request, err error) {
+			w.Header().Set("X-Minirouter", "error")
+			w.WriteHeader(http.StatusTeapot)
+			_, _ = w.Write([]byte("custom " + err.Error()))
+		})
+		g := r.WithBasePath("/sub").WithMiddleware(func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				addHeaderID(w, r)
+				next.ServeHTTP(w, r)
+			})
+		})
+		g.GET("/foo/:id", g.E(func(w http.ResponseWriter, r *http.Request) error {
+			return errors.New("boom")
+		}))
+
+		srv := httptest.NewServer(r)
+		defer srv.Close()
+
+		res, err := http.Get(srv.URL + "/sub/foo/john")
+		assertNoError(t, err)
+		assertResponse(t, res, http.StatusTeapot, "error", "john", "custom boom")
+	})
+}
@@ -0,0 +1,81 @@
+package minirouter
+
+import (
+	"net/http"
+	"strings"
+)
+
+// fallback is a handler called for the unmatched requests whose path is under basePath.
+type fallback struct {
+	basePath string
+	handler  http.Handler
+}
+
+// NotFound sets the handler called when no route matches a request whose path is under m's base-path.
+// The handler is wrapped with m's middlewares. When groups are nested, the handler of the innermost group is used.
+func (m *Mini) NotFound(handler http.Handler) {
+	m.router.NotFound = http.HandlerFunc(m.routes.serveNotFound)
+	m.routes.setFallback(&m.routes.notFound, fallback{basePath: m.basePath, handler: m.wrap(handler)})
+}
+
+// MethodNotAllowed sets the handler called when a request whose path is under m's base-path matches a route, but not
+// its method. The "Allow" header is set before the handler is called.
+// The handler is wrapped with m's middlewares. When groups are nested, the handler of the innermost group is used.
+func (m *Mini) MethodNotAllowed(handler http.Handler) {
+	m.router.MethodNotAllowed = http.HandlerFunc(m.routes.serveMethodNotAllowed)
+	m.routes.setFallback(&m.routes.methodNotAllowed, fallback{basePath: m.basePath, handler: m.wrap(handler)})
+}
+
+func (reg *registry) setFallback(fallbacks *[]fallback, f fallback) {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	for i := range *fallbacks {
+		if (*fallbacks)[i].basePath == f.basePath {
+			(*fallbacks)[i] = f
+			return
+		}
+	}
+	*fallbacks = append(*fallbacks, f)
+}
+
+// findFallback returns the handler of the fallback with the longest base-path containing path, if any.
+func (reg *registry) findFallback(fallbacks []fallback, path string) http.Handler {
+	var found *fallback
+	for i, f := range fallbacks {
+		base := strings.TrimSuffix(f.basePath, "/")
+		if base != "" && path != base && !strings.HasPrefix(path, base+"/") {
+			continue
+		}
+		if found == nil || len(base) > len(strings.TrimSuffix(found.basePath, "/")) {
+			found = &fallbacks[i]
+		}
+	}
+	if found == nil {
+		return nil
+	}
+	return found.handler
+}
+
+func (reg *registry) serveNotFound(w http.ResponseWriter, req *http.Request) {
+	reg.mu.RLock()
+	handler := reg.findFallback(reg.notFound, req.URL.Path)
+	reg.mu.RUnlock()
+
+	if handler == nil {
+		http.NotFound(w, req)
+		return
+	}
+	handler.ServeHTTP(w, req)
+}
+
+func (reg *registry) serveMethodNotAllowed(w http.ResponseWriter, req *http.Request) {
+	reg.mu.RLock()
+	handler := reg.findFallback(reg.methodNotAllowed, req.URL.Path)
+	reg.mu.RUnlock()
+
+	if handler == nil {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	handler.ServeHTTP(w, req)
+}
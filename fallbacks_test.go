@@ -0,0 +1,56 @@
+package minirouter
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMini_NotFound(t *testing.T) {
+	r := New()
+	r.GET("/app/assets/:name", func(w http.ResponseWriter, r *http.Request) {})
+	r.GET("/api/users/:id", func(w http.ResponseWriter, r *http.Request) {})
+
+	api := r.WithBasePath("/api").WithHandlerMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Minirouter", "api")
+	}))
+	api.NotFound(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"not found"}`, http.StatusNotFound)
+	}))
+	api.MethodNotAllowed(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+	}))
+
+	app := r.WithBasePath("/app")
+	app.NotFound(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("index.html"))
+	}))
+
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		status int
+		header string
+		body   string
+	}{
+		{name: "API miss", method: http.MethodGet, path: "/api/unknown", status: http.StatusNotFound, header: "api", body: `{"error":"not found"}`},
+		{name: "API base-path miss", method: http.MethodGet, path: "/api", status: http.StatusNotFound, header: "api", body: `{"error":"not found"}`},
+		{name: "API wrong method", method: http.MethodPost, path: "/api/users/42", status: http.StatusMethodNotAllowed, header: "api", body: `{"error":"method not allowed"}`},
+		{name: "App miss", method: http.MethodGet, path: "/app/some/page", status: http.StatusOK, body: "index.html"},
+		{name: "Other prefix", method: http.MethodGet, path: "/apix", status: http.StatusNotFound, body: "404 page not found"},
+		{name: "Root wrong method", method: http.MethodPost, path: "/app/assets/logo.png", status: http.StatusMethodNotAllowed, body: "Method Not Allowed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, srv.URL+tt.path, nil)
+			assertNoError(t, err)
+			res, err := http.DefaultClient.Do(req)
+			assertNoError(t, err)
+			assertResponse(t, res, tt.status, tt.header, "", tt.body)
+		})
+	}
+}
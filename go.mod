@@ -1,5 +1,5 @@
 module github.com/ofux/minirouter
 
-go 1.16
+go 1.21
 
 require github.com/julienschmidt/httprouter v1.3.0
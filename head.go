@@ -0,0 +1,59 @@
+package minirouter
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// WithAutoHEAD returns a copy of parent whose GET routes also answer HEAD requests. HEAD requests are served by the
+// GET handler, through the same middleware chain, and get the same headers as a GET request, including the
+// Content-Length of the body the handler writes, but no body.
+// The HEAD routes are not listed by Routes. An explicit HEAD route registered for the same path, before or after the
+// GET route, replaces the automatic one.
+func (m *Mini) WithAutoHEAD() *Mini {
+	newMini := m.WithBasePath("")
+	newMini.autoHEAD = true
+	return newMini
+}
+
+// serveHEAD returns a handler serving HEAD requests with handler, discarding the body it writes.
+func serveHEAD(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		hw := &headResponseWriter{ResponseWriter: w}
+		handler.ServeHTTP(hw, req)
+		hw.flush()
+	})
+}
+
+// headResponseWriter discards the body written by a handler and counts its bytes, delaying the headers until the
+// handler returns so that the Content-Length header can be set.
+type headResponseWriter struct {
+	http.ResponseWriter
+	status  int
+	written int
+}
+
+func (w *headResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+func (w *headResponseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	w.written += len(b)
+	return len(b), nil
+}
+
+// flush sends the headers, with the Content-Length of the discarded body if the handler did not set it.
+func (w *headResponseWriter) flush() {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	if w.written > 0 && w.Header().Get("Content-Length") == "" {
+		w.Header().Set("Content-Length", strconv.Itoa(w.written))
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+}
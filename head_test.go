@@ -0,0 +1,122 @@
+package minirouter
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMini_HEAD(t *testing.T) {
+	r := New()
+	r.HEAD("/foo/bar/:id", func(w http.ResponseWriter, r *http.Request) {
+		addHeaderID(w, r)
+	})
+
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	res, err := http.Head(srv.URL + "/foo/bar/john")
+	assertNoError(t, err)
+	assertResponse(t, res, 200, "", "john", "")
+}
+
+func TestMini_WithAutoHEAD(t *testing.T) {
+	body := strings.Repeat("a", 10000) // Larger than the buffer of net/http, which could compute Content-Length itself.
+
+	r := New()
+	auto := r.WithAutoHEAD().WithHandlerMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Minirouter", "bar")
+	}))
+	auto.GET("/foo/bar/:id", func(w http.ResponseWriter, r *http.Request) {
+		addHeaderID(w, r)
+		_, _ = w.Write([]byte(body))
+	})
+	auto.GET("/created", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "2")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte("OK"))
+	})
+	r.GET("/manual", func(w http.ResponseWriter, r *http.Request) {})
+
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	tests := []struct {
+		name          string
+		path          string
+		status        int
+		minirouter    string
+		id            string
+		contentLength int64
+	}{
+		{name: "Same headers as GET", path: "/foo/bar/john", status: 200, minirouter: "bar", id: "john", contentLength: 10000},
+		{name: "Status and Content-Length set by the handler", path: "/created", status: 201, minirouter: "bar", contentLength: 2},
+		{name: "Without the option", path: "/manual", status: 405, contentLength: int64(len("Method Not Allowed\n"))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := http.Head(srv.URL + tt.path)
+			assertNoError(t, err)
+			if res.ContentLength != tt.contentLength {
+				t.Errorf("Wrong Content-Length. Expected %d, got %d", tt.contentLength, res.ContentLength)
+			}
+			assertResponse(t, res, tt.status, tt.minirouter, tt.id, "")
+		})
+	}
+
+	res, err := http.Get(srv.URL + "/foo/bar/john")
+	assertNoError(t, err)
+	assertResponse(t, res, 200, "bar", "john", body)
+
+	for _, route := range r.Routes() {
+		if route.Method == http.MethodHead {
+			t.Errorf("Unexpected HEAD route %s", route.Path)
+		}
+	}
+}
+
+func TestMini_WithAutoHEAD_explicitHEAD(t *testing.T) {
+	reply := func(header string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Handler", header+" "+Params(r).ByName("id")+Params(r).ByName("name"))
+		}
+	}
+
+	r := New()
+	g := r.WithAutoHEAD()
+	g.HEAD("/before/:id", reply("explicit"))
+	g.GET("/before/:id", reply("get"))
+	g.GET("/after/:id", reply("get"))
+	g.HEAD("/after/:name", reply("explicit"))
+	g.GET("/auto/:id", reply("get"))
+
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	tests := []struct {
+		path    string
+		handler string
+	}{
+		{path: "/before/42", handler: "explicit 42"},
+		{path: "/after/42", handler: "explicit 42"},
+		{path: "/auto/42", handler: "get 42"},
+	}
+	for _, tt := range tests {
+		res, err := http.Head(srv.URL + tt.path)
+		assertNoError(t, err)
+		if got := res.Header.Get("X-Handler"); res.StatusCode != 200 || got != tt.handler {
+			t.Errorf("HEAD %s: expected 200 from handler %q, got %d from %q", tt.path, tt.handler, res.StatusCode, got)
+		}
+	}
+
+	var heads []string
+	for _, route := range r.Routes() {
+		if route.Method == http.MethodHead {
+			heads = append(heads, route.Path)
+		}
+	}
+	if len(heads) != 2 || heads[0] != "/before/:id" || heads[1] != "/after/:name" {
+		t.Errorf("Expected the explicit HEAD routes to be listed, got %v", heads)
+	}
+}
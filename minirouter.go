@@ -1,6 +1,7 @@
 package minirouter
 
 import (
+	"context"
 	"net/http"
 	"strings"
 
@@ -10,12 +11,29 @@ import (
 // Middleware wraps an http.Handler, returning a new http.Handler.
 type Middleware func(next http.Handler) http.Handler
 
+// allMethods lists the standard HTTP methods, registered by Any and Mount.
+var allMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+	http.MethodConnect,
+	http.MethodOptions,
+	http.MethodTrace,
+}
+
 // Mini adds middlewares on top of httprouter.Router
 type Mini struct {
 	router *httprouter.Router
+	routes *registry
 
-	basePath    string
-	middlewares []Middleware
+	basePath     string
+	middlewares  []Middleware
+	errorHandler ErrorHandler
+	autoHEAD     bool
+	cors         *corsPolicy
 }
 
 // New initializes a new Mini.
@@ -24,12 +42,26 @@ func New() *Mini {
 	r.RedirectFixedPath = false // Disable path auto-correction. Let's be strict.
 	return &Mini{
 		router: r,
+		routes: &registry{},
 	}
 }
 
 // ServeHTTP makes Mini implement the http.Handler interface.
 func (m *Mini) ServeHTTP(w http.ResponseWriter, req *http.Request) {
-	m.router.ServeHTTP(w, req)
+	m.routes.mu.RLock()
+	handler := m.routes.dispatch
+	m.routes.mu.RUnlock()
+
+	if handler == nil {
+		m.serveRoutes(w, req)
+		return
+	}
+	if holder, ok := req.Context().Value(routeHolderKey{}).(*routeHolder); ok && holder.route == nil {
+		// Served by a parent Mini (see MountMini), which already provided a holder for this request.
+		handler.ServeHTTP(w, req)
+		return
+	}
+	handler.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), routeHolderKey{}, &routeHolder{})))
 }
 
 // Router returns the internal httprouter.Router
@@ -48,9 +80,13 @@ func (m *Mini) WithBasePath(path string) *Mini {
 	}
 
 	return &Mini{
-		router:      m.router,
-		basePath:    m.path(path),
-		middlewares: middlewaresCopy,
+		router:       m.router,
+		routes:       m.routes,
+		basePath:     m.path(path),
+		middlewares:  middlewaresCopy,
+		errorHandler: m.errorHandler,
+		autoHEAD:     m.autoHEAD,
+		cors:         m.cors,
 	}
 }
 
@@ -62,6 +98,41 @@ func (m *Mini) WithMiddleware(middleware ...Middleware) *Mini {
 	return newMini
 }
 
+// Use adds one or more middlewares to the global middleware chain, which wraps the whole router dispatch.
+// Unlike the middlewares added with WithMiddleware, they run for every request served by the Mini, including the
+// requests that match no route, the automatic OPTIONS replies and the 405 responses. They run before the route is
+// looked up, so Params is not available to them.
+// The global chain is shared by all the copies of a Mini, whatever their base-path.
+func (m *Mini) Use(middleware ...Middleware) {
+	m.routes.mu.Lock()
+	defer m.routes.mu.Unlock()
+	m.routes.global = append(m.routes.global, middleware...)
+	m.buildDispatch()
+}
+
+// Pre adds one or more middlewares to the pre-routing chain, which runs before everything else, including the global
+// chain (see Use) and the route lookup. Pre-routing middlewares can therefore rewrite the request (eg. its URL path
+// or its method) to change the route it matches.
+// The pre-routing chain is shared by all the copies of a Mini, whatever their base-path.
+func (m *Mini) Pre(middleware ...Middleware) {
+	m.routes.mu.Lock()
+	defer m.routes.mu.Unlock()
+	m.routes.pre = append(m.routes.pre, middleware...)
+	m.buildDispatch()
+}
+
+// buildDispatch wraps the router with the global and pre-routing chains. The registry's lock must be held.
+func (m *Mini) buildDispatch() {
+	var handler http.Handler = http.HandlerFunc(m.serveRoutes)
+	for i := len(m.routes.global) - 1; i >= 0; i-- {
+		handler = m.routes.global[i](handler)
+	}
+	for i := len(m.routes.pre) - 1; i >= 0; i-- {
+		handler = m.routes.pre[i](handler)
+	}
+	m.routes.dispatch = handler
+}
+
 // WithHandlerMiddleware returns a copy of parent with an http.Handler as a new middleware.
 func (m *Mini) WithHandlerMiddleware(handler http.Handler) *Mini {
 	return m.WithMiddleware(func(next http.Handler) http.Handler {
@@ -73,49 +144,132 @@ func (m *Mini) WithHandlerMiddleware(handler http.Handler) *Mini {
 }
 
 // Handle registers a handler for the given method and path.
-func (m *Mini) Handle(method, path string, handler http.Handler, middleware ...Middleware) {
+// The returned Route can be used to give a name to the route.
+// Static segments take precedence over parameters, and parameters over catch-alls, so "/users/me" can be registered
+// along with "/users/:id". Handle panics if the route is invalid or if another route matching the same paths has
+// already been registered for the method, unless CollectConflicts has been called.
+func (m *Mini) Handle(method, path string, handler http.Handler, middleware ...Middleware) *Route {
+	return m.handle([]string{method}, path, handler, middleware, true)[0]
+}
+
+// Methods registers a func handler for the given methods and path. The handler is wrapped only once with the
+// middlewares, so that they are shared by all the methods. It returns one Route per method.
+func (m *Mini) Methods(methods []string, path string, handler http.HandlerFunc, middleware ...Middleware) []*Route {
+	return m.handle(methods, path, handler, middleware, true)
+}
+
+// Any registers a func handler for the given path and all the standard HTTP methods: GET, HEAD, POST, PUT, PATCH,
+// DELETE, CONNECT, OPTIONS and TRACE. It returns one Route per method.
+func (m *Mini) Any(path string, handler http.HandlerFunc, middleware ...Middleware) []*Route {
+	return m.handle(allMethods, path, handler, middleware, true)
+}
+
+// handle registers handler, wrapped with the inline middlewares and m's middlewares, for each of the methods.
+// Unlisted routes are left out of Routes, and therefore of the OpenAPI document.
+func (m *Mini) handle(methods []string, path string, handler http.Handler, middleware []Middleware, listed bool) []*Route {
+	handler = checkContentType(handler)
 	for i := len(middleware) - 1; i >= 0; i-- {
 		handler = middleware[i](handler)
 	}
-	for i := len(m.middlewares) - 1; i >= 0; i-- {
-		handler = m.middlewares[i](handler)
+	handler = m.wrap(handler)
+
+	source := callerSource()
+	routes := make([]*Route, len(methods))
+	for i, method := range methods {
+		route := &Route{
+			reg: m.routes,
+			info: RouteInfo{
+				Method:      method,
+				Path:        m.path(path),
+				BasePath:    m.basePath,
+				Middlewares: append(append([]Middleware(nil), m.middlewares...), middleware...),
+				Source:      source,
+			},
+			errorHandler: m.errorHandler,
+		}
+		routes[i] = route
+
+		if !m.register(route.info, withRoute(route, handler), false) {
+			continue
+		}
+		if listed {
+			m.routes.add(route)
+		}
+
+		if m.autoHEAD && method == http.MethodGet && !containsMethod(methods, http.MethodHead) {
+			head := route.info
+			head.Method = http.MethodHead
+			m.register(head, withRoute(route, serveHEAD(handler)), true)
+		}
 	}
-	m.router.Handler(method, m.path(path), handler)
+	return routes
+}
+
+func containsMethod(methods []string, method string) bool {
+	for _, m := range methods {
+		if m == method {
+			return true
+		}
+	}
+	return false
 }
 
 // HandleFunc registers a func handler for the given method and path.
-func (m *Mini) HandleFunc(method, path string, handler http.HandlerFunc, middleware ...Middleware) {
-	m.Handle(method, path, handler, middleware...)
+func (m *Mini) HandleFunc(method, path string, handler http.HandlerFunc, middleware ...Middleware) *Route {
+	return m.Handle(method, path, handler, middleware...)
 }
 
 // GET registers a GET func handler for the given path.
-func (m *Mini) GET(path string, handler http.HandlerFunc, middleware ...Middleware) {
-	m.Handle(http.MethodGet, path, handler, middleware...)
+func (m *Mini) GET(path string, handler http.HandlerFunc, middleware ...Middleware) *Route {
+	return m.Handle(http.MethodGet, path, handler, middleware...)
 }
 
 // PUT registers a PUT func handler for the given path.
-func (m *Mini) PUT(path string, handler http.HandlerFunc, middleware ...Middleware) {
-	m.Handle(http.MethodPut, path, handler, middleware...)
+func (m *Mini) PUT(path string, handler http.HandlerFunc, middleware ...Middleware) *Route {
+	return m.Handle(http.MethodPut, path, handler, middleware...)
 }
 
 // POST registers a POST func handler for the given path.
-func (m *Mini) POST(path string, handler http.HandlerFunc, middleware ...Middleware) {
-	m.Handle(http.MethodPost, path, handler, middleware...)
+func (m *Mini) POST(path string, handler http.HandlerFunc, middleware ...Middleware) *Route {
+	return m.Handle(http.MethodPost, path, handler, middleware...)
 }
 
 // PATCH registers a PATCH func handler for the given path.
-func (m *Mini) PATCH(path string, handler http.HandlerFunc, middleware ...Middleware) {
-	m.Handle(http.MethodPatch, path, handler, middleware...)
+func (m *Mini) PATCH(path string, handler http.HandlerFunc, middleware ...Middleware) *Route {
+	return m.Handle(http.MethodPatch, path, handler, middleware...)
 }
 
 // DELETE registers a DELETE func handler for the given path.
-func (m *Mini) DELETE(path string, handler http.HandlerFunc, middleware ...Middleware) {
-	m.Handle(http.MethodDelete, path, handler, middleware...)
+func (m *Mini) DELETE(path string, handler http.HandlerFunc, middleware ...Middleware) *Route {
+	return m.Handle(http.MethodDelete, path, handler, middleware...)
+}
+
+// HEAD registers a HEAD func handler for the given path.
+func (m *Mini) HEAD(path string, handler http.HandlerFunc, middleware ...Middleware) *Route {
+	return m.Handle(http.MethodHead, path, handler, middleware...)
+}
+
+// CONNECT registers a CONNECT func handler for the given path.
+func (m *Mini) CONNECT(path string, handler http.HandlerFunc, middleware ...Middleware) *Route {
+	return m.Handle(http.MethodConnect, path, handler, middleware...)
+}
+
+// TRACE registers a TRACE func handler for the given path.
+func (m *Mini) TRACE(path string, handler http.HandlerFunc, middleware ...Middleware) *Route {
+	return m.Handle(http.MethodTrace, path, handler, middleware...)
 }
 
 // OPTIONS registers a OPTIONS func handler for the given path.
-func (m *Mini) OPTIONS(path string, handler http.HandlerFunc, middleware ...Middleware) {
-	m.Handle(http.MethodOptions, path, handler, middleware...)
+func (m *Mini) OPTIONS(path string, handler http.HandlerFunc, middleware ...Middleware) *Route {
+	return m.Handle(http.MethodOptions, path, handler, middleware...)
+}
+
+// wrap wraps handler with m's middlewares.
+func (m *Mini) wrap(handler http.Handler) http.Handler {
+	for i := len(m.middlewares) - 1; i >= 0; i-- {
+		handler = m.middlewares[i](handler)
+	}
+	return handler
 }
 
 // Params returns the httprouter.Params for request.
@@ -561,3 +561,182 @@ func TestMini_HandleFunc(t *testing.T) {
 		assertResponse(t, res, 200, "bar", "john", "OK john")
 	})
 }
+
+func TestMini_Use(t *testing.T) {
+	r := New()
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			addHeader(w)
+			next.ServeHTTP(w, r)
+		})
+	})
+	g := r.WithBasePath("/sub")
+	g.GET("/foo/bar/:id", func(w http.ResponseWriter, r *http.Request) {
+		if _, err := w.Write([]byte("OK " + Params(r).ByName("id"))); err != nil {
+			t.Fatal(err)
+		}
+	}, func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			addHeaderID(w, r)
+			next.ServeHTTP(w, r)
+		})
+	})
+
+	srv := httptest.NewServer(g)
+	defer srv.Close()
+
+	res, err := http.Get(srv.URL + "/sub/foo/bar/john")
+	assertNoError(t, err)
+	assertResponse(t, res, 200, "foo", "john", "OK john")
+
+	res, err = http.Get(srv.URL + "/unknown")
+	assertNoError(t, err)
+	assertResponse(t, res, http.StatusNotFound, "foo", "", "404 page not found")
+
+	res, err = http.Post(srv.URL+"/sub/foo/bar/john", "text/plain", nil)
+	assertNoError(t, err)
+	assertResponse(t, res, http.StatusMethodNotAllowed, "foo", "", "Method Not Allowed")
+
+	req, err := http.NewRequest(http.MethodOptions, srv.URL+"/sub/foo/bar/john", nil)
+	assertNoError(t, err)
+	res, err = http.DefaultClient.Do(req)
+	assertNoError(t, err)
+	assertResponse(t, res, 200, "foo", "", "")
+}
+
+func TestMini_Pre(t *testing.T) {
+	r := New()
+	var order []string
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			order = append(order, "global "+r.URL.Path)
+			next.ServeHTTP(w, r)
+		})
+	})
+	r.Pre(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			order = append(order, "pre "+r.URL.Path)
+			r.URL.Path = strings.ToLower(strings.TrimPrefix(r.URL.Path, "/v1"))
+			next.ServeHTTP(w, r)
+		})
+	})
+	r.Pre(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if override := r.Header.Get("X-HTTP-Method-Override"); override != "" {
+				r.Method = override
+			}
+			next.ServeHTTP(w, r)
+		})
+	})
+	r.GET("/foo/bar/:id", func(w http.ResponseWriter, r *http.Request) {
+		if _, err := w.Write([]byte("OK " + Params(r).ByName("id"))); err != nil {
+			t.Fatal(err)
+		}
+	})
+	r.DELETE("/foo/bar/:id", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	res, err := http.Get(srv.URL + "/v1/Foo/Bar/john")
+	assertNoError(t, err)
+	assertResponse(t, res, 200, "", "", "OK john")
+	if got := strings.Join(order, ", "); got != "pre /v1/Foo/Bar/john, global /foo/bar/john" {
+		t.Errorf("Wrong middleware order: %s", got)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, srv.URL+"/foo/bar/john", nil)
+	assertNoError(t, err)
+	req.Header.Set("X-HTTP-Method-Override", http.MethodDelete)
+	res, err = http.DefaultClient.Do(req)
+	assertNoError(t, err)
+	assertResponse(t, res, http.StatusNoContent, "", "", "")
+}
+
+func TestMini_Methods(t *testing.T) {
+	wraps := 0
+	r := New().WithMiddleware(func(next http.Handler) http.Handler {
+		wraps++
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			addHeader(w)
+			next.ServeHTTP(w, r)
+		})
+	})
+	routes := r.Methods([]string{http.MethodGet, http.MethodPost}, "/foo/:id", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Method + " " + Params(r).ByName("id")))
+	}, func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			addHeaderID(w, r)
+			next.ServeHTTP(w, r)
+		})
+	})
+	if len(routes) != 2 || routes[0].Info().Method != http.MethodGet || routes[1].Info().Method != http.MethodPost {
+		t.Errorf("Wrong routes %v", routes)
+	}
+	if wraps != 1 {
+		t.Errorf("Expected the handler to be wrapped once, got %d", wraps)
+	}
+
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	for _, method := range []string{http.MethodGet, http.MethodPost, http.MethodPut} {
+		req, err := http.NewRequest(method, srv.URL+"/foo/john", nil)
+		assertNoError(t, err)
+		res, err := http.DefaultClient.Do(req)
+		assertNoError(t, err)
+		if method == http.MethodPut {
+			assertResponse(t, res, 405, "", "", "Method Not Allowed")
+			continue
+		}
+		assertResponse(t, res, 200, "foo", "john", method+" john")
+	}
+}
+
+func TestMini_Any(t *testing.T) {
+	r := New().WithAutoHEAD()
+	routes := r.Any("/webhook", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Minirouter", r.Method)
+	})
+	if len(routes) != len(allMethods) {
+		t.Errorf("Wrong number of routes. Expected %d, got %d", len(allMethods), len(routes))
+	}
+
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	// CONNECT requests cannot be sent to a path with http.Client.
+	for _, method := range []string{http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodOptions, http.MethodTrace} {
+		req, err := http.NewRequest(method, srv.URL+"/webhook", nil)
+		assertNoError(t, err)
+		res, err := http.DefaultClient.Do(req)
+		assertNoError(t, err)
+		assertResponse(t, res, 200, method, "", "")
+	}
+}
+
+func TestMini_TRACE(t *testing.T) {
+	r := New()
+	r.TRACE("/foo/bar/:id", func(w http.ResponseWriter, r *http.Request) {
+		addHeaderID(w, r)
+		_, _ = w.Write([]byte("OK " + Params(r).ByName("id")))
+	})
+	r.CONNECT("/foo/bar/:id", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("not expected to be here")
+	})
+
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	req, err := http.NewRequest(http.MethodTrace, srv.URL+"/foo/bar/john", nil)
+	assertNoError(t, err)
+	res, err := http.DefaultClient.Do(req)
+	assertNoError(t, err)
+	assertResponse(t, res, 200, "", "john", "OK john")
+
+	if routes := r.Routes(); len(routes) != 2 || routes[1].Method != http.MethodConnect {
+		t.Errorf("Wrong routes %v", routes)
+	}
+}
@@ -0,0 +1,105 @@
+package minirouter
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// mountParam is the name of the catch-all parameter used to mount handlers.
+const mountParam = "mountpath"
+
+// Mount registers handler for all the methods and all the paths under prefix, which is joined to m's base-path.
+// The prefix is stripped from the request's path before it is passed to handler, which is wrapped with m's
+// middlewares and the given ones. It can be used to host any http.Handler (a file server, a legacy mux...) inside a Mini.
+// Requests to the prefix itself, without trailing slash, are redirected to the prefix with a trailing slash.
+// Like with MountMini, the catch-all routes registered for the mount are not listed by Routes, so they are not part
+// of the OpenAPI document either.
+func (m *Mini) Mount(prefix string, handler http.Handler, middleware ...Middleware) {
+	path := strings.TrimSuffix(prefix, "/") + "/*" + mountParam
+	m.handle(allMethods, path, stripMountPrefix(handler), middleware, false)
+}
+
+// stripMountPrefix returns a handler serving the request to handler with the mounted path as URL path.
+func stripMountPrefix(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		path := Params(req).ByName(mountParam)
+
+		r2 := new(http.Request)
+		*r2 = *req
+		r2.URL = new(url.URL)
+		*r2.URL = *req.URL
+		r2.URL.Path = path
+		r2.URL.RawPath = ""
+		handler.ServeHTTP(w, r2)
+	})
+}
+
+// mountedMini is a sub-application mounted with MountMini.
+type mountedMini struct {
+	prefix      string
+	middlewares []Middleware
+	sub         *registry
+	// methods holds the methods whose catch-all route was registered for the mount. The other ones are served by the
+	// conflicting routes collected by CollectConflicts, so the routes of sub with these methods are ignored.
+	methods map[string]bool
+}
+
+// adapt converts the description of a route of the sub-application into the description of the route as seen from
+// the parent application.
+func (mm mountedMini) adapt(info RouteInfo) RouteInfo {
+	info.Path = mm.prefix + info.Path
+	info.BasePath = mm.prefix + info.BasePath
+	info.Middlewares = append(append([]Middleware(nil), mm.middlewares...), info.Middlewares...)
+	return info
+}
+
+// adaptRoute converts a route of the sub-application into the route as seen from the parent application.
+func (mm mountedMini) adaptRoute(route *Route) *Route {
+	return &Route{reg: route.reg, info: mm.adapt(route.Info()), errorHandler: route.errorHandler}
+}
+
+// serve returns a handler serving the request to sub, with a route holder reporting the route matched in sub to
+// the parent's holder, so that the parent's global middlewares see the route with its full path.
+func (mm mountedMini) serve(sub http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		holder := &routeHolder{adapt: mm.adaptRoute}
+		holder.outer, _ = req.Context().Value(routeHolderKey{}).(*routeHolder)
+		sub.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), routeHolderKey{}, holder)))
+	})
+}
+
+// MountMini mounts sub, an independent Mini with its own router, under prefix, which is joined to m's base-path.
+// Like with Mount, the prefix is stripped from the request's path before it is passed to sub, which is wrapped with
+// m's middlewares. The routes of sub, including the ones registered after the call to MountMini, are listed by
+// m.Routes with their full path, and their names can be used with m.URL.
+func (m *Mini) MountMini(prefix string, sub *Mini) {
+	prefix = strings.TrimSuffix(m.path(prefix), "/")
+	mm := mountedMini{
+		prefix:      prefix,
+		middlewares: m.middlewares,
+		sub:         sub.routes,
+	}
+	handler := m.wrap(stripMountPrefix(mm.serve(sub)))
+	source := callerSource()
+	mm.methods = make(map[string]bool, len(allMethods))
+	for _, method := range allMethods {
+		mm.methods[method] = m.register(RouteInfo{
+			Method:      method,
+			Path:        prefix + "/*" + mountParam,
+			BasePath:    m.basePath,
+			Middlewares: m.middlewares,
+			Source:      source,
+		}, handler, false)
+	}
+
+	m.routes.mu.Lock()
+	defer m.routes.mu.Unlock()
+	for _, registered := range mm.methods {
+		if registered {
+			m.routes.mounts = append(m.routes.mounts, mm)
+			break
+		}
+	}
+}
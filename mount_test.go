@@ -0,0 +1,135 @@
+package minirouter
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMini_Mount(t *testing.T) {
+	legacy := http.NewServeMux()
+	legacy.HandleFunc("/hello", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Method + " hello " + r.URL.Path))
+	})
+	legacy.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("index " + r.URL.Path))
+	})
+
+	r := New()
+	r.GET("/foo", func(w http.ResponseWriter, r *http.Request) {})
+	g := r.WithBasePath("/api").WithHandlerMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Minirouter", "bar")
+	}))
+	g.Mount("/legacy/", legacy)
+	if routes := r.Routes(); len(routes) != 1 || routes[0].Path != "/foo" {
+		t.Errorf("Mount routes must not be listed, got %v", routes)
+	}
+	if paths := r.OpenAPI(OpenAPIInfo{}).Paths; len(paths) != 1 {
+		t.Errorf("Mount routes must not be documented, got %v", paths)
+	}
+
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	res, err := http.Get(srv.URL + "/api/legacy/hello")
+	assertNoError(t, err)
+	assertResponse(t, res, 200, "bar", "", "GET hello /hello")
+
+	res, err = http.Post(srv.URL+"/api/legacy/hello", "text/plain", nil)
+	assertNoError(t, err)
+	assertResponse(t, res, 200, "bar", "", "POST hello /hello")
+
+	res, err = http.Get(srv.URL + "/api/legacy")
+	assertNoError(t, err)
+	assertResponse(t, res, 200, "bar", "", "index /")
+
+	res, err = http.Get(srv.URL + "/api/legacy/some/page")
+	assertNoError(t, err)
+	assertResponse(t, res, 200, "bar", "", "index /some/page")
+}
+
+func TestMini_MountMini(t *testing.T) {
+	users := New()
+	users = users.WithHandlerMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Minirouter", "users")
+	}))
+	users.GET("/:id", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("OK " + Params(r).ByName("id")))
+	}, func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			addHeaderID(w, r)
+			next.ServeHTTP(w, r)
+		})
+	}).Name("user")
+
+	r := New()
+	r.GET("/", func(w http.ResponseWriter, r *http.Request) {})
+	api := r.WithBasePath("/api")
+	api.MountMini("/users", users)
+	users.WithBasePath("/admin").POST("/", func(w http.ResponseWriter, r *http.Request) {}).Name("create-admin")
+
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	res, err := http.Get(srv.URL + "/api/users/john")
+	assertNoError(t, err)
+	assertResponse(t, res, 200, "users", "john", "OK john")
+
+	res, err = http.Get(srv.URL + "/api/users/john/unknown")
+	assertNoError(t, err)
+	assertResponse(t, res, http.StatusNotFound, "", "", "404 page not found")
+
+	routes := r.Routes()
+	if len(routes) != 3 {
+		t.Fatalf("Wrong number of routes. Expected 3, got %d", len(routes))
+	}
+	if routes[1].Path != "/api/users/:id" || routes[1].BasePath != "/api/users" || len(routes[1].Middlewares) != 2 {
+		t.Errorf("Wrong mounted route %+v", routes[1])
+	}
+	if routes[2].Path != "/api/users/admin/" || routes[2].BasePath != "/api/users/admin" {
+		t.Errorf("Wrong mounted route %+v", routes[2])
+	}
+
+	url, err := r.URL("user", "id", "42")
+	assertNoError(t, err)
+	if url != "/api/users/42" {
+		t.Errorf("Wrong URL %s", url)
+	}
+	url, err = api.URL("create-admin")
+	assertNoError(t, err)
+	if url != "/api/users/admin/" {
+		t.Errorf("Wrong URL %s", url)
+	}
+
+	t.Run("Conflicts", func(t *testing.T) {
+		noop := func(w http.ResponseWriter, r *http.Request) {}
+		sub := New()
+		sub.GET("/n", noop).Name("n")
+		sub.POST("/p", noop).Name("p")
+
+		r := New()
+		r.CollectConflicts()
+		r.Mount("/a", http.NotFoundHandler())
+		r.MountMini("/a", sub)
+		r.GET("/b/*path", noop)
+		r.MountMini("/b", sub)
+
+		var errs RouteErrors
+		if !errors.As(r.Validate(), &errs) || len(errs) != 10 {
+			t.Errorf("Expected 10 route errors, got %v", errs)
+		}
+		routes := r.Routes()
+		if len(routes) != 2 || routes[0].Path != "/b/*path" || routes[1].Path != "/b/p" {
+			t.Errorf("Expected only the routes of sub served by the mount to be listed, got %+v", routes)
+		}
+		url, err := r.URL("p")
+		assertNoError(t, err)
+		if url != "/b/p" {
+			t.Errorf("Wrong URL %s", url)
+		}
+		if _, err := r.URL("n"); err == nil {
+			t.Error("Expected no URL for a route of sub not served by the mount")
+		}
+	})
+}
@@ -0,0 +1,480 @@
+package minirouter
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OpenAPIInfo holds the general information of an OpenAPI document.
+type OpenAPIInfo struct {
+	Title       string
+	Version     string
+	Description string
+}
+
+// OpenAPIDocument is an OpenAPI 3.1 document generated from the routes registered on a Mini.
+type OpenAPIDocument struct {
+	OpenAPI    string                                  `json:"openapi"`
+	Info       openAPIInfo                             `json:"info"`
+	Paths      map[string]map[string]*openAPIOperation `json:"paths"`
+	Components *openAPIComponents                      `json:"components,omitempty"`
+}
+
+type openAPIInfo struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+type openAPIComponents struct {
+	Schemas map[string]*openAPISchema `json:"schemas,omitempty"`
+}
+
+type openAPIOperation struct {
+	OperationID string                      `json:"operationId,omitempty"`
+	Summary     string                      `json:"summary,omitempty"`
+	Description string                      `json:"description,omitempty"`
+	Tags        []string                    `json:"tags,omitempty"`
+	Parameters  []openAPIParameter          `json:"parameters,omitempty"`
+	RequestBody *openAPIRequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*openAPIResponse `json:"responses"`
+}
+
+type openAPIParameter struct {
+	Name     string         `json:"name"`
+	In       string         `json:"in"`
+	Required bool           `json:"required"`
+	Schema   *openAPISchema `json:"schema"`
+}
+
+type openAPIRequestBody struct {
+	Required bool                        `json:"required"`
+	Content  map[string]openAPIMediaType `json:"content"`
+}
+
+type openAPIResponse struct {
+	Description string                      `json:"description"`
+	Content     map[string]openAPIMediaType `json:"content,omitempty"`
+}
+
+type openAPIMediaType struct {
+	Schema *openAPISchema `json:"schema"`
+}
+
+type openAPISchema struct {
+	Ref                  string                    `json:"$ref,omitempty"`
+	Type                 string                    `json:"type,omitempty"`
+	Format               string                    `json:"format,omitempty"`
+	Pattern              string                    `json:"pattern,omitempty"`
+	Items                *openAPISchema            `json:"items,omitempty"`
+	Properties           map[string]*openAPISchema `json:"properties,omitempty"`
+	AdditionalProperties *openAPISchema            `json:"additionalProperties,omitempty"`
+	Required             []string                  `json:"required,omitempty"`
+}
+
+// openAPIMethods maps the HTTP methods supported by OpenAPI path items to their field name.
+var openAPIMethods = map[string]string{
+	http.MethodGet:     "get",
+	http.MethodPut:     "put",
+	http.MethodPost:    "post",
+	http.MethodDelete:  "delete",
+	http.MethodOptions: "options",
+	http.MethodHead:    "head",
+	http.MethodPatch:   "patch",
+	http.MethodTrace:   "trace",
+}
+
+// OpenAPI generates an OpenAPI 3.1 document describing all the routes registered so far, enriched with the
+// documentation attached to each route (see Route.Summary, Route.Request, Route.Response...).
+func (m *Mini) OpenAPI(info OpenAPIInfo) *OpenAPIDocument {
+	doc := &OpenAPIDocument{
+		OpenAPI: "3.1.0",
+		Info: openAPIInfo{
+			Title:       info.Title,
+			Version:     info.Version,
+			Description: info.Description,
+		},
+		Paths: make(map[string]map[string]*openAPIOperation),
+	}
+	schemas := &schemaGenerator{components: make(map[string]*openAPISchema), names: make(map[reflect.Type]string)}
+
+	for _, route := range m.Routes() {
+		method, ok := openAPIMethods[route.Method]
+		if !ok {
+			continue
+		}
+		path, params := openAPIPath(route.Path)
+
+		op := &openAPIOperation{
+			OperationID: route.Name,
+			Summary:     route.Doc.Summary,
+			Description: route.Doc.Description,
+			Tags:        route.Doc.Tags,
+			Responses:   make(map[string]*openAPIResponse),
+		}
+		op.Parameters = append(params, schemas.bindParameters(route.Doc.Request)...)
+		if route.Doc.Request != nil && hasBodyFields(route.Doc.Request) {
+			consumes := route.Doc.Consumes
+			if len(consumes) == 0 {
+				consumes = []string{"application/json"}
+			}
+			op.RequestBody = &openAPIRequestBody{Required: true, Content: make(map[string]openAPIMediaType, len(consumes))}
+			for _, mediaType := range consumes {
+				if isFormMediaType(mediaType) {
+					op.RequestBody.Content[mediaType] = openAPIMediaType{Schema: schemas.formSchema(route.Doc.Request)}
+				} else {
+					op.RequestBody.Content[mediaType] = openAPIMediaType{Schema: schemas.schema(route.Doc.Request)}
+				}
+			}
+		}
+		produces := route.Doc.Produces
+		if len(produces) == 0 {
+			produces = []string{"application/json"}
+		}
+		for status, typ := range route.Doc.Responses {
+			res := &openAPIResponse{Description: http.StatusText(status)}
+			if typ != nil {
+				res.Content = make(map[string]openAPIMediaType, len(produces))
+				for _, mediaType := range produces {
+					res.Content[mediaType] = openAPIMediaType{Schema: schemas.schema(typ)}
+				}
+			}
+			op.Responses[strconv.Itoa(status)] = res
+		}
+		if len(op.Responses) == 0 {
+			op.Responses["default"] = &openAPIResponse{Description: "Default response"}
+		}
+
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = make(map[string]*openAPIOperation)
+		}
+		doc.Paths[path][method] = op
+	}
+
+	if len(schemas.components) > 0 {
+		doc.Components = &openAPIComponents{Schemas: schemas.components}
+	}
+	return doc
+}
+
+// JSON returns the JSON encoding of the document.
+func (doc *OpenAPIDocument) JSON() ([]byte, error) {
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// YAML returns the YAML encoding of the document.
+func (doc *OpenAPIDocument) YAML() ([]byte, error) {
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	return jsonToYAML(b)
+}
+
+// OpenAPIHandler returns a handler serving the OpenAPI document of the router, so that it can be registered with GET
+// on any group. The document is generated on each request and is encoded in JSON, unless YAML is requested with the
+// "format=yaml" query parameter or an Accept header mentioning yaml.
+func (m *Mini) OpenAPIHandler(info OpenAPIInfo) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		doc := m.OpenAPI(info)
+
+		contentType := "application/json"
+		encode := doc.JSON
+		if req.URL.Query().Get("format") == "yaml" || strings.Contains(req.Header.Get("Accept"), "yaml") {
+			contentType = "application/yaml"
+			encode = doc.YAML
+		}
+
+		b, err := encode()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", contentType)
+		_, _ = w.Write(b)
+	}
+}
+
+// openAPIPath converts a route path into an OpenAPI path, and returns its parameters.
+// Both named parameters (":id", "{id:[0-9]+}", ":id<int>") and catch-all parameters ("*filepath") are converted to
+// "{id}" and "{filepath}". Constraints are converted to the schema of the parameter.
+func openAPIPath(path string) (string, []openAPIParameter) {
+	p, err := parsePattern(path)
+	if err != nil {
+		return path, nil
+	}
+
+	var b strings.Builder
+	var params []openAPIParameter
+	for _, s := range p.segments {
+		b.WriteString("/" + s.prefix)
+		if s.kind == staticSegment {
+			continue
+		}
+		b.WriteString("{" + s.name + "}")
+
+		schema := &openAPISchema{Type: "string"}
+		switch s.typ {
+		case "int", "uint":
+			schema.Type = "integer"
+		case "uuid":
+			schema.Format = "uuid"
+		default:
+			if s.expr != "" {
+				schema.Pattern = "^(?:" + s.expr + ")$"
+			}
+		}
+		params = append(params, openAPIParameter{Name: s.name, In: "path", Required: true, Schema: schema})
+	}
+	return b.String(), params
+}
+
+// schemaGenerator derives JSON schemas from Go types. Named struct types are stored as components and referenced,
+// which also takes care of recursive types.
+type schemaGenerator struct {
+	components map[string]*openAPISchema
+	// names maps the types stored as components to their component name (see componentName).
+	names map[reflect.Type]string
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func (g *schemaGenerator) schema(t reflect.Type) *openAPISchema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return &openAPISchema{Type: "string", Format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &openAPISchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &openAPISchema{Type: "integer"}
+	case reflect.Int32:
+		return &openAPISchema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &openAPISchema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &openAPISchema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &openAPISchema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &openAPISchema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &openAPISchema{Type: "string", Format: "byte"}
+		}
+		return &openAPISchema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return &openAPISchema{Type: "object", AdditionalProperties: g.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		name, ok := g.names[t]
+		if !ok {
+			name = g.componentName(t)
+			g.names[t] = name
+			g.components[name] = &openAPISchema{} // Placeholder for recursive types.
+			*g.components[name] = *g.structSchema(t)
+		}
+		return &openAPISchema{Ref: "#/components/schemas/" + name}
+	default:
+		return &openAPISchema{}
+	}
+}
+
+var (
+	// packageQualifier matches the package paths qualifying the type arguments of generic type names, and the
+	// suffixes of the types declared in functions.
+	packageQualifier = regexp.MustCompile(`(?:[\w.-]+/)*\w+\.|·\d+`)
+	// invalidComponentChars matches the characters not allowed in the names of OpenAPI components.
+	invalidComponentChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+)
+
+// componentName returns a component name for t, which is not used by another type yet. It is the name of the type,
+// without the package paths of its type arguments if it is generic (eg. "Page_Item" for Page[pkg/path.Item]), and
+// with a numeric suffix if types with the same name come from different packages.
+func (g *schemaGenerator) componentName(t reflect.Type) string {
+	base := packageQualifier.ReplaceAllString(t.Name(), "")
+	base = strings.Trim(invalidComponentChars.ReplaceAllString(base, "_"), "_")
+	name := base
+	for i := 2; g.components[name] != nil; i++ {
+		name = base + strconv.Itoa(i)
+	}
+	return name
+}
+
+// structSchema returns the schema of a struct. Fields bound from other parts of the request than the body (see Bind)
+// are left out, while fields bound from a form body are kept, since encoding/json also decodes them.
+func (g *schemaGenerator) structSchema(t reflect.Type) *openAPISchema {
+	s := &openAPISchema{Type: "object", Properties: make(map[string]*openAPISchema)}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if (field.PkgPath != "" && !field.Anonymous) || isBoundOutsideBody(field) {
+			continue
+		}
+		name, opts := parseTag(field.Tag.Get("json"))
+		if name == "-" && opts == "" {
+			continue
+		}
+		if field.Anonymous && name == "" {
+			ft := field.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				embedded := g.structSchema(ft)
+				for k, v := range embedded.Properties {
+					s.Properties[k] = v
+				}
+				s.Required = append(s.Required, embedded.Required...)
+				continue
+			}
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		s.Properties[name] = g.schema(field.Type)
+		if !strings.Contains(opts, "omitempty") && field.Type.Kind() != reflect.Ptr {
+			s.Required = append(s.Required, name)
+		}
+	}
+	sort.Strings(s.Required)
+	return s
+}
+
+// bindParameters returns the query and header parameters of t, the type of a request bound with Bind. Path
+// parameters are left out, since they are given by the path of the route.
+func (g *schemaGenerator) bindParameters(t reflect.Type) []openAPIParameter {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var params []openAPIParameter
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			params = append(params, g.bindParameters(field.Type)...)
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		for _, in := range []string{"query", "header"} {
+			if name, ok := field.Tag.Lookup(in); ok {
+				params = append(params, openAPIParameter{
+					Name: name, In: in, Required: isRequired(field), Schema: g.schema(field.Type),
+				})
+			}
+		}
+	}
+	return params
+}
+
+// formSchema returns the schema of the form body of t, the type of a request bound with Bind: an object with a
+// property for each field tagged with `form`.
+func (g *schemaGenerator) formSchema(t reflect.Type) *openAPISchema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	s := &openAPISchema{Type: "object", Properties: make(map[string]*openAPISchema)}
+	if t.Kind() != reflect.Struct {
+		return s
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			embedded := g.formSchema(field.Type)
+			for k, v := range embedded.Properties {
+				s.Properties[k] = v
+			}
+			s.Required = append(s.Required, embedded.Required...)
+			continue
+		}
+		name, ok := field.Tag.Lookup("form")
+		if !ok || field.PkgPath != "" {
+			continue
+		}
+		s.Properties[name] = g.schema(field.Type)
+		if isRequired(field) {
+			s.Required = append(s.Required, name)
+		}
+	}
+	sort.Strings(s.Required)
+	return s
+}
+
+// isFormMediaType reports whether the bodies of mediaType are forms, whose fields are bound with the `form` tag.
+func isFormMediaType(mediaType string) bool {
+	return mediaType == "application/x-www-form-urlencoded" || mediaType == "multipart/form-data"
+}
+
+// isRequired reports whether the validate tag of the field has the required rule.
+func isRequired(field reflect.StructField) bool {
+	for _, rule := range splitRules(field.Tag.Get("validate")) {
+		if rule == "required" {
+			return true
+		}
+	}
+	return false
+}
+
+// isBoundOutsideBody reports whether the field is bound from other parts of the request than the body (see Bind).
+func isBoundOutsideBody(field reflect.StructField) bool {
+	for _, source := range []string{"path", "query", "header"} {
+		if _, ok := field.Tag.Lookup(source); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// hasBodyFields reports whether t, the type of a request, has a body: it is not a struct, or it has fields that are
+// not bound from other parts of the request.
+func hasBodyFields(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t == timeType {
+		return true
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if isBoundOutsideBody(field) {
+			continue
+		}
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			if hasBodyFields(field.Type) {
+				return true
+			}
+			continue
+		}
+		if name, _ := parseTag(field.Tag.Get("json")); field.PkgPath == "" && name != "-" {
+			return true
+		}
+	}
+	return false
+}
+
+// parseTag splits a struct tag into its name and its comma-separated options.
+func parseTag(tag string) (string, string) {
+	if i := strings.Index(tag, ","); i >= 0 {
+		return tag[:i], tag[i+1:]
+	}
+	return tag, ""
+}
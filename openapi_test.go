@@ -0,0 +1,261 @@
+package minirouter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testUser struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name,omitempty"`
+	Friends   []testUser `json:"friends,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+type testCreateUser struct {
+	Name string `json:"name"`
+}
+
+type testAddMember struct {
+	GroupID int      `path:"id"`
+	Notify  bool     `query:"notify"`
+	Roles   []string `query:"role"`
+	Token   string   `header:"X-Token" validate:"required"`
+	Name    string   `json:"name"`
+}
+
+type testPostForm struct {
+	Redirect string   `query:"redirect"`
+	Name     string   `form:"name" validate:"required"`
+	Tags     []string `form:"tag"`
+}
+
+type testPage[T any] struct {
+	Items []T `json:"items"`
+}
+
+type testGetMembers struct {
+	GroupID int `path:"id"`
+	Page    int `query:"page"`
+}
+
+func TestMini_OpenAPI_bindParameters(t *testing.T) {
+	noop := func(w http.ResponseWriter, r *http.Request) {}
+
+	r := New()
+	r.POST("/groups/:id/members", noop).Request(testAddMember{})
+	r.GET("/groups/:id/members", noop).Request(testGetMembers{})
+
+	doc := r.OpenAPI(OpenAPIInfo{Title: "Test", Version: "1.0.0"})
+
+	add := doc.Paths["/groups/{id}/members"]["post"]
+	var params []string
+	for _, p := range add.Parameters {
+		params = append(params, fmt.Sprintf("%s:%s:%t:%s", p.In, p.Name, p.Required, p.Schema.Type))
+	}
+	if want := "path:id:true:string, query:notify:false:boolean, query:role:false:array, header:X-Token:true:string"; strings.Join(params, ", ") != want {
+		t.Errorf("Wrong parameters. Expected %s, got %v", want, params)
+	}
+	member := doc.Components.Schemas["testAddMember"]
+	if add.RequestBody == nil || member == nil || len(member.Properties) != 1 || member.Properties["name"] == nil {
+		t.Errorf("Wrong request body schema %+v", member)
+	}
+
+	get := doc.Paths["/groups/{id}/members"]["get"]
+	if get.RequestBody != nil || len(get.Parameters) != 2 || get.Parameters[1].Name != "page" || get.Parameters[1].In != "query" {
+		t.Errorf("Wrong operation %+v", get)
+	}
+
+	t.Run("Form", func(t *testing.T) {
+		r := New()
+		r.POST("/form", noop).Request(testPostForm{}).Consumes("application/x-www-form-urlencoded", "multipart/form-data")
+
+		doc := r.OpenAPI(OpenAPIInfo{Title: "Test", Version: "1.0.0"})
+
+		op := doc.Paths["/form"]["post"]
+		if len(op.Parameters) != 1 || op.Parameters[0].Name != "redirect" || op.RequestBody == nil {
+			t.Fatalf("Wrong operation %+v", op)
+		}
+		for _, mediaType := range []string{"application/x-www-form-urlencoded", "multipart/form-data"} {
+			schema := op.RequestBody.Content[mediaType].Schema
+			if schema == nil || len(schema.Properties) != 2 || schema.Properties["name"] == nil ||
+				schema.Properties["tag"] == nil || schema.Properties["tag"].Type != "array" || strings.Join(schema.Required, ",") != "name" {
+				t.Errorf("Wrong %s schema %+v", mediaType, schema)
+			}
+		}
+	})
+}
+
+func TestMini_OpenAPI_componentNames(t *testing.T) {
+	noop := func(w http.ResponseWriter, r *http.Request) {}
+
+	// minirouterUser is the package-level testUser, which is shadowed by another type with the same name.
+	type minirouterUser = testUser
+	type testUser struct {
+		Login string `json:"login"`
+	}
+
+	r := New()
+	r.GET("/users/:id", noop).Response(http.StatusOK, &minirouterUser{})
+	r.GET("/users", noop).Response(http.StatusOK, testPage[testUser]{})
+	r.GET("/accounts", noop).Response(http.StatusOK, testPage[*testCreateUser]{})
+	r.GET("/me", noop).Response(http.StatusOK, testUser{})
+
+	doc := r.OpenAPI(OpenAPIInfo{Title: "Test", Version: "1.0.0"})
+
+	refs := make(map[string]string)
+	for path, name := range map[string]string{"/users/{id}": "user", "/users": "page", "/accounts": "accounts", "/me": "me"} {
+		refs[name] = doc.Paths[path]["get"].Responses["200"].Content["application/json"].Schema.Ref
+	}
+	want := map[string]string{
+		"user":     "#/components/schemas/testUser",
+		"page":     "#/components/schemas/testPage_testUser",
+		"accounts": "#/components/schemas/testPage_testCreateUser",
+		"me":       "#/components/schemas/testUser2",
+	}
+	for name, ref := range want {
+		if refs[name] != ref {
+			t.Errorf("Wrong %s component ref. Expected %s, got %s", name, ref, refs[name])
+		}
+	}
+	if page := doc.Components.Schemas["testPage_testUser"]; page == nil || page.Properties["items"].Items.Ref != want["me"] {
+		t.Errorf("Wrong generic component %+v", page)
+	}
+	if user := doc.Components.Schemas["testUser2"]; user == nil || user.Properties["login"] == nil {
+		t.Errorf("Wrong component for a type named like another one: %+v", user)
+	}
+	for name := range doc.Components.Schemas {
+		if !regexp.MustCompile(`^[a-zA-Z0-9._-]+$`).MatchString(name) {
+			t.Errorf("Invalid component name %q", name)
+		}
+	}
+}
+
+func TestMini_OpenAPI(t *testing.T) {
+	noop := func(w http.ResponseWriter, r *http.Request) {}
+
+	r := New()
+	api := r.WithBasePath("/api")
+	api.GET("/users/:id", noop).
+		Name("getUser").
+		Summary("Get a user").
+		Tags("users").
+		Response(http.StatusOK, testUser{})
+	api.POST("/users", noop).
+		Request(testCreateUser{}).
+		Response(http.StatusCreated, &testUser{}).
+		Response(http.StatusBadRequest, nil).
+		Produces("application/json", "application/xml")
+	api.GET("/files/*filepath", noop)
+	api.GET("/orders/{id:[0-9]+}/items/:item<uuid>", noop)
+
+	doc := r.OpenAPI(OpenAPIInfo{Title: "Test", Version: "1.0.0"})
+
+	if doc.OpenAPI != "3.1.0" || doc.Info.Title != "Test" || doc.Info.Version != "1.0.0" {
+		t.Errorf("Wrong document header: %+v", doc)
+	}
+
+	getUser := doc.Paths["/api/users/{id}"]["get"]
+	if getUser == nil {
+		t.Fatalf("Missing operation GET /api/users/{id} in %v", doc.Paths)
+	}
+	if getUser.OperationID != "getUser" || getUser.Summary != "Get a user" || len(getUser.Tags) != 1 {
+		t.Errorf("Wrong operation: %+v", getUser)
+	}
+	if len(getUser.Parameters) != 1 || getUser.Parameters[0].Name != "id" || getUser.Parameters[0].In != "path" {
+		t.Errorf("Wrong parameters: %+v", getUser.Parameters)
+	}
+	if ref := getUser.Responses["200"].Content["application/json"].Schema.Ref; ref != "#/components/schemas/testUser" {
+		t.Errorf("Wrong response schema ref: %s", ref)
+	}
+
+	createUser := doc.Paths["/api/users"]["post"]
+	if createUser == nil || createUser.RequestBody == nil {
+		t.Fatalf("Missing request body in POST /api/users")
+	}
+	if res, ok := createUser.Responses["201"]; !ok || len(res.Content) != 2 || res.Content["application/xml"].Schema == nil {
+		t.Errorf("Missing or wrong 201 response: %+v", res)
+	}
+	if res := createUser.Responses["400"]; res == nil || res.Content != nil {
+		t.Errorf("Expected 400 response without content, got %+v", res)
+	}
+
+	if files := doc.Paths["/api/files/{filepath}"]["get"]; files == nil || files.Responses["default"] == nil {
+		t.Errorf("Missing or wrong catch-all operation: %+v", files)
+	}
+
+	orders := doc.Paths["/api/orders/{id}/items/{item}"]["get"]
+	if orders == nil || len(orders.Parameters) != 2 {
+		t.Fatalf("Missing or wrong constrained operation: %+v", orders)
+	}
+	if schema := orders.Parameters[0].Schema; schema.Pattern != "^(?:[0-9]+)$" {
+		t.Errorf("Wrong regexp constrained parameter schema: %+v", schema)
+	}
+	if schema := orders.Parameters[1].Schema; schema.Format != "uuid" {
+		t.Errorf("Wrong type constrained parameter schema: %+v", schema)
+	}
+
+	user := doc.Components.Schemas["testUser"]
+	if user == nil || user.Type != "object" {
+		t.Fatalf("Missing testUser schema")
+	}
+	if user.Properties["createdAt"].Format != "date-time" {
+		t.Errorf("Wrong createdAt schema: %+v", user.Properties["createdAt"])
+	}
+	if user.Properties["friends"].Items.Ref != "#/components/schemas/testUser" {
+		t.Errorf("Wrong friends schema: %+v", user.Properties["friends"])
+	}
+	if strings.Join(user.Required, ",") != "createdAt,id" {
+		t.Errorf("Wrong required fields: %v", user.Required)
+	}
+}
+
+func TestMini_OpenAPIHandler(t *testing.T) {
+	r := New()
+	r.GET("/users/:id", func(w http.ResponseWriter, r *http.Request) {}).Summary("Get a user")
+	r.GET("/openapi", r.OpenAPIHandler(OpenAPIInfo{Title: "Test", Version: "1.0.0"}))
+
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	t.Run("JSON", func(t *testing.T) {
+		res, err := http.Get(srv.URL + "/openapi")
+		assertNoError(t, err)
+		defer res.Body.Close()
+		if ct := res.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Wrong content type %s", ct)
+		}
+		var doc map[string]interface{}
+		assertNoError(t, json.NewDecoder(res.Body).Decode(&doc))
+		if doc["openapi"] != "3.1.0" {
+			t.Errorf("Wrong document: %v", doc)
+		}
+	})
+
+	t.Run("YAML", func(t *testing.T) {
+		res, err := http.Get(srv.URL + "/openapi?format=yaml")
+		assertNoError(t, err)
+		body, err := io.ReadAll(res.Body)
+		res.Body.Close()
+		assertNoError(t, err)
+		if ct := res.Header.Get("Content-Type"); ct != "application/yaml" {
+			t.Errorf("Wrong content type %s", ct)
+		}
+		for _, want := range []string{
+			"openapi: \"3.1.0\"\n",
+			"  /users/{id}:\n    get:\n      summary: \"Get a user\"\n      parameters:\n        - name: \"id\"\n          in: \"path\"\n",
+			"        default:\n          description: \"Default response\"\n",
+		} {
+			if !strings.Contains(string(body), want) {
+				t.Errorf("Expected YAML document to contain %q, got:\n%s", want, body)
+			}
+		}
+	})
+}
@@ -0,0 +1,249 @@
+package minirouter
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// routeEntry is a route as seen by the dispatcher.
+type routeEntry struct {
+	info    RouteInfo
+	pattern pattern
+	handler http.Handler
+	// overlay is true if httprouter refused the route, because it has a static segment where another route has a
+	// parameter (or the other way around). Such routes are matched by Mini itself, before httprouter.
+	overlay bool
+	// cors is the CORS policy of the group the route was registered on, if any (see WithCORS).
+	cors *corsPolicy
+	// auto is true for the automatic HEAD routes of GET routes (see WithAutoHEAD), which explicit HEAD routes replace.
+	auto bool
+}
+
+// insert registers handler for the route described by info, either in httprouter or in the overlay. If the route is
+// invalid, it returns the reason why, along with the conflicting route if any. The registry's lock must be held.
+// Explicit and automatic HEAD routes matching the same paths do not conflict: the explicit route is kept, whatever the
+// registration order.
+func (m *Mini) insert(info RouteInfo, handler http.Handler, auto bool) (string, *RouteInfo) {
+	p, err := parsePattern(info.Path)
+	if err != nil {
+		return err.Error(), nil
+	}
+	for _, entry := range m.routes.entries {
+		if entry.info.Method != info.Method || entry.pattern.shape() != p.shape() {
+			continue
+		}
+		switch {
+		case auto && !entry.auto:
+			return "", nil
+		case entry.auto && !auto:
+			entry.info, entry.pattern, entry.handler, entry.cors, entry.auto = info, p, handler, m.cors, false
+			return "", nil
+		}
+		existing := entry.info
+		return "a route matching the same paths is already registered", &existing
+	}
+
+	entry := &routeEntry{info: info, pattern: p, handler: handler, cors: m.cors, auto: auto}
+	routerHandler := handler
+	if auto {
+		routerHandler = m.routes.replaceable(entry)
+	}
+	if p.constrained() {
+		routerHandler = m.checkConstraints(p, handler)
+		m.routes.constrained++
+	}
+	if !tryHandler(m.router, info.Method, p.routerPath(), routerHandler) {
+		entry.overlay = true
+		if m.routes.overlays == nil {
+			m.routes.overlays = make(map[string]int)
+		}
+		m.routes.overlays[info.Method]++
+	}
+	m.routes.entries = append(m.routes.entries, entry)
+	return "", nil
+}
+
+// checkConstraints returns a handler serving the request to handler only if the parameters satisfy the constraints
+// of p. Otherwise, the request is served as an unmatched request (see serveUnmatched).
+func (m *Mini) checkConstraints(p pattern, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if p.checkParams(Params(req)) {
+			handler.ServeHTTP(w, req)
+			return
+		}
+		m.serveUnmatched(w, req)
+	})
+}
+
+// replaceable returns a handler serving the request with the current handler of entry, an automatic HEAD route which
+// may have been replaced by an explicit HEAD route since it was registered in httprouter. The parameters are renamed
+// after the pattern of the explicit route, which matches the same paths but may name its parameters differently.
+func (reg *registry) replaceable(entry *routeEntry) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		reg.mu.RLock()
+		handler, p := entry.handler, entry.pattern
+		reg.mu.RUnlock()
+
+		params := Params(req)
+		renamed := make(httprouter.Params, 0, len(params))
+		for _, s := range p.segments {
+			if s.kind != staticSegment && len(renamed) < len(params) {
+				renamed = append(renamed, httprouter.Param{Key: s.name, Value: params[len(renamed)].Value})
+			}
+		}
+		if len(renamed) > 0 {
+			req = req.WithContext(context.WithValue(req.Context(), httprouter.ParamsKey, renamed))
+		}
+		handler.ServeHTTP(w, req)
+	})
+}
+
+// tryHandler registers handler in router, and returns false if router panicked.
+func tryHandler(router *httprouter.Router, method, path string, handler http.Handler) (ok bool) {
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+	}()
+	router.Handler(method, path, handler)
+	return true
+}
+
+// serveRoutes dispatches the request either to an overlay route, or to httprouter. CORS preflight requests to the
+// routes registered on a group configured with WithCORS are answered beforehand.
+// httprouter does not know about the overlay routes nor the constraints, so when there are some, the requests
+// httprouter has no route for are answered by serveUnmatched instead, to get the right 405 and OPTIONS replies.
+// The overlay is only looked up for methods having overlay routes: the route with the highest precedence among all
+// the routes matching the request (see pattern.precedes) is served by Mini if it is an overlay route. Otherwise it is
+// the only httprouter route matching the request, and httprouter serves it.
+func (m *Mini) serveRoutes(w http.ResponseWriter, req *http.Request) {
+	if isPreflight(req) {
+		if policy, methods := m.routes.preflight(req); policy != nil {
+			policy.servePreflight(w, req, methods)
+			return
+		}
+	}
+
+	m.routes.mu.RLock()
+	var best *routeEntry
+	var bestParams httprouter.Params
+	if m.routes.overlays[req.Method] > 0 {
+		for _, entry := range m.routes.entries {
+			if entry.info.Method != req.Method || (best != nil && !entry.pattern.precedes(best.pattern)) {
+				continue
+			}
+			if params, ok := entry.pattern.match(req.URL.Path); ok {
+				best, bestParams = entry, params
+			}
+		}
+	}
+	var bestHandler http.Handler
+	if best != nil {
+		bestHandler = best.handler
+	}
+	complete := len(m.routes.overlays) == 0 && m.routes.constrained == 0
+	m.routes.mu.RUnlock()
+
+	if best == nil || !best.overlay {
+		if complete || m.routerHandles(req) {
+			m.router.ServeHTTP(w, req)
+			return
+		}
+		m.serveUnmatched(w, req)
+		return
+	}
+	if len(bestParams) > 0 {
+		req = req.WithContext(context.WithValue(req.Context(), httprouter.ParamsKey, bestParams))
+	}
+	bestHandler.ServeHTTP(w, req)
+}
+
+// routerHandles reports whether httprouter has a route for the request, or redirects it to the path with or without
+// trailing slash.
+func (m *Mini) routerHandles(req *http.Request) bool {
+	handle, _, tsr := m.router.Lookup(req.Method, req.URL.Path)
+	return handle != nil || (tsr && m.router.RedirectTrailingSlash && req.Method != http.MethodConnect)
+}
+
+// serveUnmatched answers a request no route matches for its method like httprouter does, but computing the allowed
+// methods from all the routes, including the overlay routes and the constraints of the parameters: automatic
+// OPTIONS replies and 405 responses when routes match the path for other methods, 404 responses otherwise.
+func (m *Mini) serveUnmatched(w http.ResponseWriter, req *http.Request) {
+	allow := m.routes.allowHeader(req.URL.Path, req.Method)
+	if req.Method == http.MethodOptions && m.router.HandleOPTIONS {
+		if allow != "" {
+			w.Header().Set("Allow", allow)
+			if m.router.GlobalOPTIONS != nil {
+				m.router.GlobalOPTIONS.ServeHTTP(w, req)
+			}
+			return
+		}
+	} else if m.router.HandleMethodNotAllowed {
+		if allow != "" {
+			w.Header().Set("Allow", allow)
+			if m.router.MethodNotAllowed != nil {
+				m.router.MethodNotAllowed.ServeHTTP(w, req)
+			} else {
+				http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			}
+			return
+		}
+	}
+
+	if m.router.RedirectFixedPath && req.Method != http.MethodConnect {
+		// Let httprouter try to fix the path.
+		m.router.ServeHTTP(w, req)
+		return
+	}
+	if m.router.NotFound != nil {
+		m.router.NotFound.ServeHTTP(w, req)
+		return
+	}
+	http.NotFound(w, req)
+}
+
+// allowHeader returns the Allow header of the response to a request with method to path, formatted like httprouter
+// does, or an empty string if no route matches path for another method.
+func (reg *registry) allowHeader(path, method string) string {
+	var allowed []string
+	for _, m := range reg.allowedMethods(path, nil) {
+		if m != method && m != http.MethodOptions {
+			allowed = append(allowed, m)
+		}
+	}
+	if len(allowed) == 0 {
+		return ""
+	}
+	allowed = append(allowed, http.MethodOptions)
+	sort.Strings(allowed)
+	return strings.Join(allowed, ", ")
+}
+
+// allowedMethods returns the methods of the routes matching path for which keep returns true (all of them if keep
+// is nil), in the order of allMethods.
+func (reg *registry) allowedMethods(path string, keep func(entry *routeEntry) bool) []string {
+	reg.mu.RLock()
+	defer reg.mu.RUnlock()
+	matched := make(map[string]bool)
+	for _, entry := range reg.entries {
+		if _, ok := entry.pattern.match(path); ok && (keep == nil || keep(entry)) {
+			matched[entry.info.Method] = true
+		}
+	}
+
+	var methods []string
+	for _, method := range allMethods {
+		if matched[method] {
+			methods = append(methods, method)
+			delete(matched, method)
+		}
+	}
+	for method := range matched {
+		methods = append(methods, method)
+	}
+	return methods
+}
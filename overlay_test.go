@@ -0,0 +1,185 @@
+package minirouter
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMini_precedence(t *testing.T) {
+	reply := func(msg string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(msg + " " + Params(r).ByName("id") + Params(r).ByName("path")))
+		}
+	}
+
+	// Register in both orders to make sure precedence does not depend on registration order.
+	r := New()
+	r.GET("/users/me", reply("me"))
+	r.GET("/users/:id", reply("user"))
+	r.GET("/users/:id/files", reply("files"))
+	r.GET("/users/*path", reply("catch-all"))
+	g := r.WithBasePath("/groups")
+	g.GET("/*path", reply("catch-all"))
+	g.GET("/:id", reply("group"))
+	g.GET("/admins", reply("admins"))
+
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	tests := []struct {
+		path   string
+		status int
+		body   string
+	}{
+		{path: "/users/me", status: 200, body: "me"},
+		{path: "/users/42", status: 200, body: "user 42"},
+		{path: "/users/42/files", status: 200, body: "files 42"},
+		{path: "/users/42/other", status: 200, body: "catch-all /42/other"},
+		{path: "/groups/admins", status: 200, body: "admins"},
+		{path: "/groups/42", status: 200, body: "group 42"},
+		{path: "/groups/42/other", status: 200, body: "catch-all /42/other"},
+		{path: "/unknown", status: 404, body: "404 page not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			res, err := http.Get(srv.URL + tt.path)
+			assertNoError(t, err)
+			assertResponse(t, res, tt.status, "", "", tt.body)
+		})
+	}
+}
+
+func TestMini_constraints(t *testing.T) {
+	reply := func(msg string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(msg + " " + Params(r).ByName("id") + Params(r).ByName("slug") + Params(r).ByName("name")))
+		}
+	}
+
+	var middlewareRan bool
+	r := New()
+	r.GET("/orders/{id:[0-9]+}", reply("order"))
+	r.GET("/orders/{slug:[a-z]+}", reply("slug"))
+	r.GET("/files/:name<uuid>", reply("file"), func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			middlewareRan = true
+			next.ServeHTTP(w, r)
+		})
+	})
+	r.GET("/users/{id:[0-9]+}", reply("user"))
+	r.GET("/users/:name", reply("name"))
+
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	tests := []struct {
+		path   string
+		status int
+		body   string
+	}{
+		{path: "/orders/42", status: 200, body: "order 42"},
+		{path: "/orders/abc", status: 200, body: "slug abc"},
+		{path: "/orders/ABC", status: 404, body: "404 page not found"},
+		{path: "/files/0b5a4c9e-5a1f-4d8e-9a43-4fd8e0a6c7b1", status: 200, body: "file 0b5a4c9e-5a1f-4d8e-9a43-4fd8e0a6c7b1"},
+		{path: "/users/42", status: 200, body: "user 42"},
+		{path: "/users/john", status: 200, body: "name john"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			res, err := http.Get(srv.URL + tt.path)
+			assertNoError(t, err)
+			assertResponse(t, res, tt.status, "", "", tt.body)
+		})
+	}
+
+	t.Run("Unsatisfied constraint with another method", func(t *testing.T) {
+		r := New()
+		r.GET("/items/{id:[0-9]+}", reply("item"))
+		r.DELETE("/items/:id", reply("delete"))
+		r.PUT("/items/{id:[0-9]+}", reply("put"))
+		r.GET("/orders/{id:[0-9]+}", reply("order"))
+		srv := httptest.NewServer(r)
+		defer srv.Close()
+
+		tests := []struct {
+			method string
+			path   string
+			status int
+			allow  string
+		}{
+			{method: http.MethodPost, path: "/items/42", status: 405, allow: "DELETE, GET, OPTIONS, PUT"},
+			{method: http.MethodGet, path: "/items/abc", status: 405, allow: "DELETE, OPTIONS"},
+			{method: http.MethodPut, path: "/items/abc", status: 405, allow: "DELETE, OPTIONS"},
+			{method: http.MethodPost, path: "/items/abc", status: 405, allow: "DELETE, OPTIONS"},
+			{method: http.MethodOptions, path: "/items/abc", status: 200, allow: "DELETE, OPTIONS"},
+			{method: http.MethodDelete, path: "/orders/42", status: 405, allow: "GET, OPTIONS"},
+			{method: http.MethodDelete, path: "/orders/abc", status: 404},
+			{method: http.MethodGet, path: "/orders/abc", status: 404},
+		}
+		for _, tt := range tests {
+			req, err := http.NewRequest(tt.method, srv.URL+tt.path, nil)
+			assertNoError(t, err)
+			res, err := http.DefaultClient.Do(req)
+			assertNoError(t, err)
+			res.Body.Close()
+			if res.StatusCode != tt.status || res.Header.Get("Allow") != tt.allow {
+				t.Errorf("%s %s: expected %d with Allow %q, got %d with Allow %q",
+					tt.method, tt.path, tt.status, tt.allow, res.StatusCode, res.Header.Get("Allow"))
+			}
+		}
+	})
+
+	t.Run("Unsatisfied constraint", func(t *testing.T) {
+		middlewareRan = false
+		res, err := http.Get(srv.URL + "/files/john")
+		assertNoError(t, err)
+		assertResponse(t, res, 404, "", "", "404 page not found")
+		if middlewareRan {
+			t.Error("Middlewares must not run for requests that do not satisfy the constraints")
+		}
+	})
+}
+
+func TestMini_overlayMethodNotAllowed(t *testing.T) {
+	ok := func(w http.ResponseWriter, r *http.Request) {}
+
+	// httprouter only knows about the route registered first, so both orders must give the same answers.
+	meFirst := New()
+	meFirst.GET("/users/me", ok)
+	meFirst.GET("/users/:id", ok)
+	idFirst := New()
+	idFirst.GET("/users/:id", ok)
+	idFirst.GET("/users/me", ok)
+
+	for name, r := range map[string]*Mini{"Static first": meFirst, "Param first": idFirst} {
+		t.Run(name, func(t *testing.T) {
+			srv := httptest.NewServer(r)
+			defer srv.Close()
+
+			tests := []struct {
+				method string
+				path   string
+				status int
+				allow  string
+			}{
+				{method: http.MethodDelete, path: "/users/42", status: 405, allow: "GET, OPTIONS"},
+				{method: http.MethodDelete, path: "/users/me", status: 405, allow: "GET, OPTIONS"},
+				{method: http.MethodOptions, path: "/users/42", status: 200, allow: "GET, OPTIONS"},
+				{method: http.MethodOptions, path: "/users/me", status: 200, allow: "GET, OPTIONS"},
+				{method: http.MethodDelete, path: "/groups/42", status: 404},
+			}
+			for _, tt := range tests {
+				req, err := http.NewRequest(tt.method, srv.URL+tt.path, nil)
+				assertNoError(t, err)
+				res, err := http.DefaultClient.Do(req)
+				assertNoError(t, err)
+				res.Body.Close()
+				if res.StatusCode != tt.status || res.Header.Get("Allow") != tt.allow {
+					t.Errorf("%s %s: expected %d with Allow %q, got %d with Allow %q",
+						tt.method, tt.path, tt.status, tt.allow, res.StatusCode, res.Header.Get("Allow"))
+				}
+			}
+		})
+	}
+}
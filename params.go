@@ -0,0 +1,254 @@
+package minirouter
+
+import (
+	"encoding"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// ParamError is the error returned when a path parameter is missing or has an invalid value.
+type ParamError struct {
+	Name  string
+	Value string
+	// Reason describes why the value is invalid.
+	Reason string
+	// Err is the underlying parsing error, if any.
+	Err error
+}
+
+func (e *ParamError) Error() string {
+	if e.Value == "" {
+		return fmt.Sprintf("path parameter '%s': %s", e.Name, e.Reason)
+	}
+	return fmt.Sprintf("path parameter '%s' ('%s'): %s", e.Name, e.Value, e.Reason)
+}
+
+// Unwrap returns the underlying parsing error.
+func (e *ParamError) Unwrap() error {
+	return e.Err
+}
+
+// StatusCode returns http.StatusBadRequest.
+func (e *ParamError) StatusCode() int {
+	return http.StatusBadRequest
+}
+
+// TypedParams wraps httprouter.Params with accessors returning typed values.
+// All the accessors return a *ParamError if the parameter is missing or cannot be parsed.
+type TypedParams struct {
+	httprouter.Params
+}
+
+// Typed returns the TypedParams of the request.
+func Typed(req *http.Request) TypedParams {
+	return TypedParams{Params: Params(req)}
+}
+
+// String returns the value of the parameter, which must not be empty.
+func (ps TypedParams) String(name string) (string, error) {
+	value := ps.ByName(name)
+	if value == "" {
+		return "", &ParamError{Name: name, Reason: "missing value"}
+	}
+	return value, nil
+}
+
+// Int returns the value of the parameter as an int. Values out of the range of int are errors.
+func (ps TypedParams) Int(name string) (int, error) {
+	v, err := ps.parseInt(name, strconv.IntSize)
+	return int(v), err
+}
+
+// Int64 returns the value of the parameter as an int64.
+func (ps TypedParams) Int64(name string) (int64, error) {
+	return ps.parseInt(name, 64)
+}
+
+func (ps TypedParams) parseInt(name string, bitSize int) (int64, error) {
+	value, err := ps.String(name)
+	if err != nil {
+		return 0, err
+	}
+	v, err := strconv.ParseInt(value, 10, bitSize)
+	if err != nil {
+		return 0, &ParamError{Name: name, Value: value, Reason: "not an integer", Err: err}
+	}
+	return v, nil
+}
+
+// Bool returns the value of the parameter as a bool. See strconv.ParseBool for the accepted values.
+func (ps TypedParams) Bool(name string) (bool, error) {
+	value, err := ps.String(name)
+	if err != nil {
+		return false, err
+	}
+	v, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, &ParamError{Name: name, Value: value, Reason: "not a boolean", Err: err}
+	}
+	return v, nil
+}
+
+// UUID returns the value of the parameter as a UUID.
+func (ps TypedParams) UUID(name string) (UUID, error) {
+	value, err := ps.String(name)
+	if err != nil {
+		return UUID{}, err
+	}
+	v, err := ParseUUID(value)
+	if err != nil {
+		return UUID{}, &ParamError{Name: name, Value: value, Reason: "not a UUID", Err: err}
+	}
+	return v, nil
+}
+
+// Time returns the value of the parameter as a time.Time, parsed with the given layout (eg. time.RFC3339).
+func (ps TypedParams) Time(name, layout string) (time.Time, error) {
+	value, err := ps.String(name)
+	if err != nil {
+		return time.Time{}, err
+	}
+	v, err := time.Parse(layout, value)
+	if err != nil {
+		return time.Time{}, &ParamError{Name: name, Value: value, Reason: "not a time with layout " + layout, Err: err}
+	}
+	return v, nil
+}
+
+// Enum returns the value of the parameter, which must be one of the allowed values.
+func (ps TypedParams) Enum(name string, allowed ...string) (string, error) {
+	value, err := ps.String(name)
+	if err != nil {
+		return "", err
+	}
+	for _, a := range allowed {
+		if value == a {
+			return value, nil
+		}
+	}
+	return "", &ParamError{Name: name, Value: value, Reason: "must be one of " + strings.Join(allowed, ", ")}
+}
+
+// BindPath fills the fields of the struct pointed to by dst that have a `path:"<name>"` tag with the values of the
+// corresponding path parameters. Supported field types are strings, booleans, integers, floats, time.Time (RFC 3339),
+// types implementing encoding.TextUnmarshaler (such as UUID), and pointers to these types.
+// It returns a *ParamError if a parameter is missing or cannot be converted.
+func BindPath(req *http.Request, dst interface{}) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return errors.New("minirouter: BindPath expects a pointer to a struct")
+	}
+	v = v.Elem()
+
+	ps := Params(req)
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		name, ok := field.Tag.Lookup("path")
+		if !ok || field.PkgPath != "" {
+			continue
+		}
+		value := ps.ByName(name)
+		if value == "" {
+			return &ParamError{Name: name, Reason: "missing value"}
+		}
+		if err := setValue(v.Field(i), value); err != nil {
+			return &ParamError{Name: name, Value: value, Reason: "not a valid " + field.Type.String(), Err: err}
+		}
+	}
+	return nil
+}
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// setValue converts raw into the type of v, and sets v.
+func setValue(v reflect.Value, raw string) error {
+	if v.Kind() == reflect.Ptr {
+		elem := reflect.New(v.Type().Elem())
+		if err := setValue(elem.Elem(), raw); err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+	}
+	if reflect.PtrTo(v.Type()).Implements(textUnmarshalerType) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(raw))
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+// UUID is a universally unique identifier, as defined by RFC 4122.
+type UUID [16]byte
+
+// ParseUUID parses a UUID in its canonical form (xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx).
+func ParseUUID(s string) (UUID, error) {
+	var u UUID
+	if len(s) != 36 || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return u, errors.New("invalid UUID format")
+	}
+	b, err := hex.DecodeString(s[0:8] + s[9:13] + s[14:18] + s[19:23] + s[24:])
+	if err != nil {
+		return u, errors.New("invalid UUID format")
+	}
+	copy(u[:], b)
+	return u, nil
+}
+
+// String returns the canonical form of the UUID.
+func (u UUID) String() string {
+	s := hex.EncodeToString(u[:])
+	return s[0:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:]
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (u UUID) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (u *UUID) UnmarshalText(text []byte) error {
+	v, err := ParseUUID(string(text))
+	if err != nil {
+		return err
+	}
+	*u = v
+	return nil
+}
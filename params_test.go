@@ -0,0 +1,116 @@
+package minirouter
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+func requestWithParams(params ...string) *http.Request {
+	var ps httprouter.Params
+	for i := 0; i < len(params); i += 2 {
+		ps = append(ps, httprouter.Param{Key: params[i], Value: params[i+1]})
+	}
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	return req.WithContext(context.WithValue(req.Context(), httprouter.ParamsKey, ps))
+}
+
+func TestTypedParams(t *testing.T) {
+	ps := Typed(requestWithParams(
+		"id", "42",
+		"big", "9000000000",
+		"huge", "99999999999999999999",
+		"flag", "true",
+		"uuid", "0b5a4c9e-5a1f-4d8e-9a43-4fd8e0a6c7b1",
+		"date", "2021-03-04",
+		"status", "active",
+		"bad", "x",
+	))
+
+	if v, err := ps.Int("id"); err != nil || v != 42 {
+		t.Errorf("Int() = %v, %v", v, err)
+	}
+	if v, err := ps.Int64("big"); err != nil || v != 9000000000 {
+		t.Errorf("Int64() = %v, %v", v, err)
+	}
+	if v, err := ps.Bool("flag"); err != nil || !v {
+		t.Errorf("Bool() = %v, %v", v, err)
+	}
+	if v, err := ps.UUID("uuid"); err != nil || v.String() != "0b5a4c9e-5a1f-4d8e-9a43-4fd8e0a6c7b1" {
+		t.Errorf("UUID() = %v, %v", v, err)
+	}
+	if v, err := ps.Time("date", "2006-01-02"); err != nil || !v.Equal(time.Date(2021, 3, 4, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Time() = %v, %v", v, err)
+	}
+	if v, err := ps.Enum("status", "active", "inactive"); err != nil || v != "active" {
+		t.Errorf("Enum() = %v, %v", v, err)
+	}
+
+	tests := []struct {
+		name string
+		call func() error
+		want string
+	}{
+		{name: "Missing", call: func() error { _, err := ps.Int("missing"); return err }, want: "path parameter 'missing': missing value"},
+		{name: "Int", call: func() error { _, err := ps.Int("bad"); return err }, want: "path parameter 'bad' ('x'): not an integer"},
+		{name: "Int out of range", call: func() error { _, err := ps.Int("huge"); return err }, want: "path parameter 'huge' ('99999999999999999999'): not an integer"},
+		{name: "Bool", call: func() error { _, err := ps.Bool("bad"); return err }, want: "path parameter 'bad' ('x'): not a boolean"},
+		{name: "UUID", call: func() error { _, err := ps.UUID("bad"); return err }, want: "path parameter 'bad' ('x'): not a UUID"},
+		{name: "Enum", call: func() error { _, err := ps.Enum("bad", "a", "b"); return err }, want: "path parameter 'bad' ('x'): must be one of a, b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			var paramErr *ParamError
+			if !errors.As(err, &paramErr) {
+				t.Fatalf("Expected a *ParamError, got %v", err)
+			}
+			if err.Error() != tt.want {
+				t.Errorf("Wrong error. Expected %q, got %q", tt.want, err.Error())
+			}
+			if StatusCode(err) != http.StatusBadRequest {
+				t.Errorf("Wrong status code %d", StatusCode(err))
+			}
+		})
+	}
+}
+
+func TestBindPath(t *testing.T) {
+	type target struct {
+		ID      int       `path:"id"`
+		Name    string    `path:"name"`
+		UUID    UUID      `path:"uuid"`
+		Active  *bool     `path:"active"`
+		Since   time.Time `path:"since"`
+		Ignored string
+	}
+
+	req := requestWithParams(
+		"id", "42",
+		"name", "john",
+		"uuid", "0b5a4c9e-5a1f-4d8e-9a43-4fd8e0a6c7b1",
+		"active", "1",
+		"since", "2021-03-04T05:06:07Z",
+	)
+	var got target
+	assertNoError(t, BindPath(req, &got))
+	if got.ID != 42 || got.Name != "john" || got.UUID.String() != "0b5a4c9e-5a1f-4d8e-9a43-4fd8e0a6c7b1" ||
+		got.Active == nil || !*got.Active || got.Since.Year() != 2021 || got.Ignored != "" {
+		t.Errorf("Wrong bound struct %+v", got)
+	}
+
+	err := BindPath(requestWithParams("id", "x"), &got)
+	var paramErr *ParamError
+	if !errors.As(err, &paramErr) || paramErr.Name != "id" {
+		t.Errorf("Expected a *ParamError for 'id', got %v", err)
+	}
+
+	if err := BindPath(req, got); err == nil {
+		t.Error("Expected an error for a non-pointer destination")
+	}
+}
@@ -0,0 +1,250 @@
+package minirouter
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type segmentKind int
+
+// Segment kinds, by increasing precedence.
+const (
+	catchAllSegment segmentKind = iota
+	paramSegment
+	prefixedParamSegment
+	staticSegment
+)
+
+// ParamTypes maps the names of the types that can constrain a parameter (eg. "/files/:name<uuid>") to the regular
+// expression its values must match. It must only be modified before routes are registered.
+var ParamTypes = map[string]string{
+	"int":   `-?[0-9]+`,
+	"uint":  `[0-9]+`,
+	"uuid":  `[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`,
+	"alpha": `[a-zA-Z]+`,
+	"alnum": `[a-zA-Z0-9]+`,
+	"hex":   `[0-9a-fA-F]+`,
+}
+
+// segment is a segment of a pattern, ie. the part of a path between two slashes.
+type segment struct {
+	kind segmentKind
+	// prefix is the static part of the segment, which is the whole segment for static segments.
+	prefix string
+	// name is the name of the parameter, for parameter and catch-all segments.
+	name string
+	// constraint is the regular expression the value of the parameter must match, if any.
+	constraint *regexp.Regexp
+	// expr is the source of constraint, and typ the name of its type if it was given as a type.
+	expr, typ string
+}
+
+// pattern is a parsed route path. Besides the syntax of httprouter (eg. "/users/:id/files/*filepath"), parameters
+// can be constrained with a regular expression ("/orders/{id:[0-9]+}") or a type of ParamTypes ("/files/:name<uuid>").
+// "{id}" is the same as ":id".
+type pattern struct {
+	segments []segment
+}
+
+// parsePattern parses path, enforcing the same rules as httprouter.
+func parsePattern(path string) (pattern, error) {
+	if !strings.HasPrefix(path, "/") {
+		return pattern{}, errors.New("path must begin with '/'")
+	}
+
+	parts := strings.Split(path[1:], "/")
+	p := pattern{segments: make([]segment, len(parts))}
+	for i, part := range parts {
+		s, err := parseSegment(part)
+		if err != nil {
+			return pattern{}, err
+		}
+		if s.kind == catchAllSegment {
+			if i != len(parts)-1 {
+				return pattern{}, errors.New("catch-all routes are only allowed at the end of the path")
+			}
+			if s.prefix != "" {
+				return pattern{}, errors.New("no / before catch-all")
+			}
+		}
+		p.segments[i] = s
+	}
+	return p, nil
+}
+
+func parseSegment(part string) (segment, error) {
+	wildcard := strings.IndexAny(part, ":*{")
+	if wildcard < 0 {
+		return segment{kind: staticSegment, prefix: part}, nil
+	}
+
+	s := segment{kind: paramSegment, prefix: part[:wildcard], name: part[wildcard+1:]}
+	switch part[wildcard] {
+	case '{':
+		if !strings.HasSuffix(s.name, "}") {
+			return segment{}, errors.New("missing closing '}' in path segment '" + part + "'")
+		}
+		s.name = strings.TrimSuffix(s.name, "}")
+		if i := strings.Index(s.name, ":"); i >= 0 {
+			s.name, s.expr = s.name[:i], s.name[i+1:]
+		}
+	case ':':
+		if i := strings.Index(s.name, "<"); i >= 0 {
+			if !strings.HasSuffix(s.name, ">") {
+				return segment{}, errors.New("missing closing '>' in path segment '" + part + "'")
+			}
+			s.name, s.typ = s.name[:i], s.name[i+1:len(s.name)-1]
+			expr, ok := ParamTypes[s.typ]
+			if !ok {
+				return segment{}, fmt.Errorf("unknown parameter type '%s', expected one of %s", s.typ, paramTypeNames())
+			}
+			s.expr = expr
+		}
+	case '*':
+		s.kind = catchAllSegment
+	}
+
+	if s.name == "" {
+		return segment{}, errors.New("wildcards must be named with a non-empty name")
+	}
+	if strings.ContainsAny(s.name, ":*{}<>") {
+		return segment{}, errors.New("only one wildcard per path segment is allowed")
+	}
+	if s.expr != "" {
+		re, err := regexp.Compile("^(?:" + s.expr + ")$")
+		if err != nil {
+			return segment{}, fmt.Errorf("invalid constraint for parameter '%s': %w", s.name, err)
+		}
+		s.constraint = re
+	}
+	if s.kind == paramSegment && s.prefix != "" {
+		s.kind = prefixedParamSegment
+	}
+	return s, nil
+}
+
+func paramTypeNames() string {
+	names := make([]string, 0, len(ParamTypes))
+	for name := range ParamTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// rank returns the precedence of the segment: its kind first, then whether it is constrained.
+func (s segment) rank() int {
+	rank := int(s.kind) * 2
+	if s.constraint != nil {
+		rank++
+	}
+	return rank
+}
+
+// matches reports whether value satisfies the constraint of the segment, if any.
+func (s segment) matches(value string) bool {
+	return s.constraint == nil || s.constraint.MatchString(value)
+}
+
+// shape returns a representation of the pattern without the names of its parameters. Two patterns with the same
+// shape match exactly the same paths, so they cannot be registered together for the same method.
+func (p pattern) shape() string {
+	var b strings.Builder
+	for _, s := range p.segments {
+		b.WriteString("/" + s.prefix)
+		switch s.kind {
+		case paramSegment, prefixedParamSegment:
+			b.WriteString(":" + s.expr)
+		case catchAllSegment:
+			b.WriteString("*")
+		}
+	}
+	return b.String()
+}
+
+// routerPath returns the path as understood by httprouter, without the constraints.
+func (p pattern) routerPath() string {
+	var b strings.Builder
+	for _, s := range p.segments {
+		b.WriteString("/" + s.prefix)
+		switch s.kind {
+		case paramSegment, prefixedParamSegment:
+			b.WriteString(":" + s.name)
+		case catchAllSegment:
+			b.WriteString("*" + s.name)
+		}
+	}
+	return b.String()
+}
+
+// constrained reports whether some parameters of the pattern are constrained.
+func (p pattern) constrained() bool {
+	for _, s := range p.segments {
+		if s.constraint != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// checkParams reports whether the values of the parameters satisfy their constraints.
+func (p pattern) checkParams(params httprouter.Params) bool {
+	for _, s := range p.segments {
+		if s.constraint != nil && !s.matches(params.ByName(s.name)) {
+			return false
+		}
+	}
+	return true
+}
+
+// match reports whether path matches the pattern, and returns the values of its parameters.
+func (p pattern) match(path string) (httprouter.Params, bool) {
+	if !strings.HasPrefix(path, "/") {
+		return nil, false
+	}
+
+	var params httprouter.Params
+	parts := strings.Split(path[1:], "/")
+	for i, s := range p.segments {
+		if i >= len(parts) {
+			return nil, false
+		}
+		part := parts[i]
+		switch s.kind {
+		case staticSegment:
+			if part != s.prefix {
+				return nil, false
+			}
+		case paramSegment, prefixedParamSegment:
+			if len(part) <= len(s.prefix) || !strings.HasPrefix(part, s.prefix) || !s.matches(part[len(s.prefix):]) {
+				return nil, false
+			}
+			params = append(params, httprouter.Param{Key: s.name, Value: part[len(s.prefix):]})
+		case catchAllSegment:
+			// Like httprouter, the value of catch-all parameters starts with a slash.
+			params = append(params, httprouter.Param{Key: s.name, Value: "/" + strings.Join(parts[i:], "/")})
+			return params, true
+		}
+	}
+	if len(parts) != len(p.segments) {
+		return nil, false
+	}
+	return params, true
+}
+
+// precedes reports whether p takes precedence over other when both match a path: the first segment that differs
+// decides, static segments taking precedence over parameters and parameters over catch-alls. Constrained parameters
+// take precedence over unconstrained ones.
+func (p pattern) precedes(other pattern) bool {
+	for i := 0; i < len(p.segments) && i < len(other.segments); i++ {
+		if p.segments[i].rank() != other.segments[i].rank() {
+			return p.segments[i].rank() > other.segments[i].rank()
+		}
+	}
+	return len(p.segments) > len(other.segments)
+}
@@ -0,0 +1,108 @@
+package minirouter
+
+import (
+	"testing"
+)
+
+func TestParsePattern(t *testing.T) {
+	tests := []struct {
+		path    string
+		shape   string
+		wantErr bool
+	}{
+		{path: "/", shape: "/"},
+		{path: "/users/:id", shape: "/users/:"},
+		{path: "/users/:id/files/*filepath", shape: "/users/:/files/*"},
+		{path: "/users/user_:name", shape: "/users/user_:"},
+		{path: "/orders/{id:[0-9]{1,4}}", shape: "/orders/:[0-9]{1,4}"},
+		{path: "/orders/{id}", shape: "/orders/:"},
+		{path: "/files/:name<uint>", shape: "/files/:[0-9]+"},
+		{path: "users", wantErr: true},
+		{path: "/orders/{id:[0-9]+", wantErr: true},
+		{path: "/orders/{id:[0-9+}", wantErr: true},
+		{path: "/files/:name<unknown>", wantErr: true},
+		{path: "/files/:name<uuid", wantErr: true},
+		{path: "/users/:", wantErr: true},
+		{path: "/users/:id:name", wantErr: true},
+		{path: "/files/*filepath/more", wantErr: true},
+		{path: "/files/file_*filepath", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			p, err := parsePattern(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parsePattern() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && p.shape() != tt.shape {
+				t.Errorf("shape() = %v, want %v", p.shape(), tt.shape)
+			}
+		})
+	}
+}
+
+func TestPattern_match(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+		params  string
+	}{
+		{pattern: "/", path: "/", want: true},
+		{pattern: "/users/:id", path: "/users/42", want: true, params: "id=42"},
+		{pattern: "/users/:id", path: "/users/", want: false},
+		{pattern: "/users/:id", path: "/users/42/", want: false},
+		{pattern: "/users/user_:name", path: "/users/user_john", want: true, params: "name=john"},
+		{pattern: "/users/user_:name", path: "/users/john", want: false},
+		{pattern: "/files/*filepath", path: "/files/a/b", want: true, params: "filepath=/a/b"},
+		{pattern: "/files/*filepath", path: "/files/", want: true, params: "filepath=/"},
+		{pattern: "/files/*filepath", path: "/files", want: false},
+		{pattern: "/orders/{id:[0-9]+}", path: "/orders/42", want: true, params: "id=42"},
+		{pattern: "/orders/{id:[0-9]+}", path: "/orders/42a", want: false},
+		{pattern: "/files/:name<uuid>", path: "/files/0b5a4c9e-5a1f-4d8e-9a43-4fd8e0a6c7b1", want: true, params: "name=0b5a4c9e-5a1f-4d8e-9a43-4fd8e0a6c7b1"},
+		{pattern: "/files/:name<uuid>", path: "/files/john", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.path, func(t *testing.T) {
+			p, err := parsePattern(tt.pattern)
+			assertNoError(t, err)
+			params, ok := p.match(tt.path)
+			if ok != tt.want {
+				t.Fatalf("match() = %v, want %v", ok, tt.want)
+			}
+			var got string
+			for _, param := range params {
+				got += param.Key + "=" + param.Value
+			}
+			if got != tt.params {
+				t.Errorf("match() params = %v, want %v", got, tt.params)
+			}
+		})
+	}
+}
+
+func TestPattern_precedes(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{a: "/users/me", b: "/users/:id", want: true},
+		{a: "/users/:id", b: "/users/me", want: false},
+		{a: "/users/:id", b: "/users/*path", want: true},
+		{a: "/users/me", b: "/users/*path", want: true},
+		{a: "/users/user_:name", b: "/users/:id", want: true},
+		{a: "/:kind/me", b: "/users/:id", want: false},
+		{a: "/orders/{id:[0-9]+}", b: "/orders/:id", want: true},
+		{a: "/orders/:id", b: "/orders/:id<int>", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+" "+tt.b, func(t *testing.T) {
+			a, err := parsePattern(tt.a)
+			assertNoError(t, err)
+			b, err := parsePattern(tt.b)
+			assertNoError(t, err)
+			if got := a.precedes(b); got != tt.want {
+				t.Errorf("precedes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package minirouter
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"runtime/debug"
+)
+
+// ProblemContentType is the media type of problem documents.
+const ProblemContentType = "application/problem+json"
+
+// Problem is a problem document as defined by RFC 7807. It implements error, so that it can be returned by a
+// HandlerFuncE and rendered by ProblemErrorHandler.
+type Problem struct {
+	// Type is a URI reference identifying the problem type. Defaults to "about:blank" when empty.
+	Type string `json:"type,omitempty"`
+	// Title is a short, human-readable summary of the problem type.
+	Title string `json:"title,omitempty"`
+	// Status is the HTTP status code of the response.
+	Status int `json:"status,omitempty"`
+	// Detail is a human-readable explanation specific to this occurrence of the problem.
+	Detail string `json:"detail,omitempty"`
+	// Instance is a URI reference identifying this occurrence of the problem.
+	Instance string `json:"instance,omitempty"`
+	// Extensions holds additional members of the problem document.
+	Extensions map[string]interface{} `json:"-"`
+}
+
+// NewProblem returns a Problem with the given status, whose title is the status text.
+func NewProblem(status int, detail string) *Problem {
+	return &Problem{
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+	}
+}
+
+func (p *Problem) Error() string {
+	if p.Detail != "" {
+		return p.Title + ": " + p.Detail
+	}
+	return p.Title
+}
+
+// StatusCode returns the status of the problem.
+func (p *Problem) StatusCode() int {
+	if p.Status == 0 {
+		return http.StatusInternalServerError
+	}
+	return p.Status
+}
+
+// MarshalJSON encodes the problem along with its extension members.
+func (p *Problem) MarshalJSON() ([]byte, error) {
+	type problem Problem // Prevents infinite recursion.
+	b, err := json.Marshal((*problem)(p))
+	if err != nil || len(p.Extensions) == 0 {
+		return b, err
+	}
+
+	members := make(map[string]interface{}, len(p.Extensions)+5)
+	for k, v := range p.Extensions {
+		members[k] = v
+	}
+	var standard map[string]interface{}
+	if err := json.Unmarshal(b, &standard); err != nil {
+		return nil, err
+	}
+	for k, v := range standard {
+		members[k] = v
+	}
+	return json.Marshal(members)
+}
+
+// WriteProblem writes p as an application/problem+json response.
+func WriteProblem(w http.ResponseWriter, p *Problem) {
+	b, err := json.Marshal(p)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(p.StatusCode())
+	_, _ = w.Write(b)
+}
+
+// ProblemErrorHandler is an ErrorHandler writing errors as problem documents.
+// A Problem found in err's chain is written as is, as well as the Problem returned by the first error of the chain
+// having a Problem() *Problem method (such as ValidationError). Otherwise, a problem is built with the status code
+// given by StatusCode; like in DefaultErrorHandler, the error's message is only used as detail if the error has a
+// status code.
+func ProblemErrorHandler(w http.ResponseWriter, r *http.Request, err error) {
+	var p *Problem
+	if errors.As(err, &p) {
+		WriteProblem(w, p)
+		return
+	}
+	var converter interface{ Problem() *Problem }
+	if errors.As(err, &converter) {
+		WriteProblem(w, converter.Problem())
+		return
+	}
+
+	var sc interface{ StatusCode() int }
+	if errors.As(err, &sc) {
+		WriteProblem(w, NewProblem(sc.StatusCode(), err.Error()))
+		return
+	}
+	WriteProblem(w, NewProblem(http.StatusInternalServerError, ""))
+}
+
+// ProblemNotFound is an http.Handler writing a 404 problem document.
+var ProblemNotFound http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	WriteProblem(w, NewProblem(http.StatusNotFound, ""))
+})
+
+// ProblemMethodNotAllowed is an http.Handler writing a 405 problem document.
+var ProblemMethodNotAllowed http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	WriteProblem(w, NewProblem(http.StatusMethodNotAllowed, ""))
+})
+
+// ProblemPanicHandler is an httprouter panic handler logging the panic and writing a 500 problem document.
+func ProblemPanicHandler(w http.ResponseWriter, r *http.Request, rcv interface{}) {
+	log.Printf("minirouter: panic serving %s %s: %v\n%s", r.Method, r.URL.Path, rcv, debug.Stack())
+	WriteProblem(w, NewProblem(http.StatusInternalServerError, ""))
+}
+
+// UseProblemDetails configures m so that not-found and method-not-allowed responses under its base-path are problem
+// documents (see Mini.NotFound and Mini.MethodNotAllowed). It also sets the panic handler of the underlying router,
+// which is global. Use WithErrorHandler(ProblemErrorHandler) to render the errors returned by handlers the same way.
+func (m *Mini) UseProblemDetails() {
+	m.NotFound(ProblemNotFound)
+	m.MethodNotAllowed(ProblemMethodNotAllowed)
+	m.router.PanicHandler = ProblemPanicHandler
+}
@@ -0,0 +1,80 @@
+package minirouter
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestProblem_MarshalJSON(t *testing.T) {
+	p := NewProblem(http.StatusForbidden, "not enough credit")
+	p.Type = "https://example.com/probs/out-of-credit"
+	p.Extensions = map[string]interface{}{"balance": 30, "status": 999}
+
+	b, err := json.Marshal(p)
+	assertNoError(t, err)
+
+	want := `{"balance":30,"detail":"not enough credit","status":403,"title":"Forbidden","type":"https://example.com/probs/out-of-credit"}`
+	if string(b) != want {
+		t.Errorf("Wrong JSON. Expected %s, got %s", want, b)
+	}
+}
+
+func TestMini_UseProblemDetails(t *testing.T) {
+	defer log.SetOutput(log.Writer())
+	log.SetOutput(io.Discard)
+
+	r := New().WithErrorHandler(ProblemErrorHandler)
+	r.UseProblemDetails()
+	r.GET("/foo", r.E(func(w http.ResponseWriter, r *http.Request) error {
+		return NewHTTPError(http.StatusConflict, errors.New("already exists"))
+	}))
+	r.GET("/bar", r.E(func(w http.ResponseWriter, r *http.Request) error {
+		return errors.New("secret")
+	}))
+	r.GET("/panic", func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})
+
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		status int
+		detail string
+	}{
+		{name: "Not found", method: http.MethodGet, path: "/unknown", status: http.StatusNotFound},
+		{name: "Method not allowed", method: http.MethodPost, path: "/foo", status: http.StatusMethodNotAllowed},
+		{name: "Panic", method: http.MethodGet, path: "/panic", status: http.StatusInternalServerError},
+		{name: "Error with status code", method: http.MethodGet, path: "/foo", status: http.StatusConflict, detail: "already exists"},
+		{name: "Error without status code", method: http.MethodGet, path: "/bar", status: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, srv.URL+tt.path, nil)
+			assertNoError(t, err)
+			res, err := http.DefaultClient.Do(req)
+			assertNoError(t, err)
+			defer res.Body.Close()
+
+			if res.StatusCode != tt.status {
+				t.Errorf("Wrong status code. Expected %d, got %d", tt.status, res.StatusCode)
+			}
+			if ct := res.Header.Get("Content-Type"); ct != ProblemContentType {
+				t.Errorf("Wrong content type %s", ct)
+			}
+			var p Problem
+			assertNoError(t, json.NewDecoder(res.Body).Decode(&p))
+			if p.Status != tt.status || p.Title != http.StatusText(tt.status) || p.Detail != tt.detail {
+				t.Errorf("Wrong problem %+v", p)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package minirouter
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"runtime/debug"
+)
+
+// PanicError is the error written by Recover when a handler panics. As it has no status code, the error handlers
+// write it as a 500 response without leaking the panic value.
+type PanicError struct {
+	// Value is the value the handler panicked with.
+	Value interface{}
+	// Stack is the stack trace of the goroutine that panicked.
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Unwrap returns the value the handler panicked with if it is an error.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// Recover is a Middleware recovering from the panics of the middlewares and handlers it wraps. The panic is logged
+// with its stack trace, along with the method and the path of the route matched by the request, and a *PanicError is
+// written by the ErrorHandler of the route (see WithErrorHandler), or by DefaultErrorHandler. If the response has
+// already been started, nothing more is written to it.
+// Panics with http.ErrAbortHandler are not recovered, so that the server aborts the response silently.
+//
+// Recover can be added to a group with WithMiddleware, or to all the routes with Use, which also recovers from the
+// panics of the global middlewares registered after it.
+func Recover(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		rw := NewResponseWriter(w)
+		defer func() {
+			rcv := recover()
+			if rcv == nil {
+				return
+			}
+			if rcv == http.ErrAbortHandler {
+				panic(rcv)
+			}
+
+			err := &PanicError{Value: rcv, Stack: debug.Stack()}
+			route := "no route"
+			if r := routeFromRequest(req); r != nil {
+				info := r.Info()
+				route = info.Method + " " + info.Path
+			}
+			log.Printf("minirouter: panic serving %s %s (%s): %v\n%s", req.Method, req.URL.Path, route, rcv, err.Stack)
+
+			if !rw.Written() {
+				handleError(rw, req, err)
+			}
+		}()
+		next.ServeHTTP(rw, req)
+	})
+}
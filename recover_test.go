@@ -0,0 +1,81 @@
+package minirouter
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRecover(t *testing.T) {
+	var logs bytes.Buffer
+	defer log.SetOutput(log.Writer())
+	log.SetOutput(&logs)
+
+	r := New()
+	r.Use(Recover, func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			next.ServeHTTP(w, req)
+			if req.URL.Query().Get("panic") == "after" {
+				panic("global middleware")
+			}
+		})
+	})
+	r.GET("/boom/:id", func(w http.ResponseWriter, r *http.Request) {
+		panic("handler")
+	})
+	r.GET("/partial", func(w http.ResponseWriter, r *http.Request) {
+		addHeader(w)
+		_, _ = w.Write([]byte("partial"))
+		panic("partial")
+	})
+	r.GET("/ok", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("OK"))
+	})
+	r.WithErrorHandler(ProblemErrorHandler).WithMiddleware(Recover).GET("/problem", func(w http.ResponseWriter, r *http.Request) {
+		panic("problem")
+	})
+
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	tests := []struct {
+		name       string
+		path       string
+		status     int
+		minirouter string
+		body       string
+		log        string
+	}{
+		{
+			name: "Panic in a handler", path: "/boom/42", status: 500, body: "Internal Server Error",
+			log: "panic serving GET /boom/42 (GET /boom/:id): handler",
+		}, {
+			name: "Panic after the response started", path: "/partial", status: 200, minirouter: "foo", body: "partial",
+			log: "panic serving GET /partial (GET /partial): partial",
+		}, {
+			name: "Panic in a global middleware", path: "/ok?panic=after", status: 200, body: "OK",
+			log: "panic serving GET /ok (GET /ok): global middleware",
+		}, {
+			name: "Panic without route", path: "/unknown?panic=after", status: 404, body: "404 page not found",
+			log: "panic serving GET /unknown (no route): global middleware",
+		}, {
+			name: "Error handler of the route", path: "/problem", status: 500,
+			body: `{"title":"Internal Server Error","status":500}`,
+			log:  "panic serving GET /problem (GET /problem): problem",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs.Reset()
+			res, err := http.Get(srv.URL + tt.path)
+			assertNoError(t, err)
+			assertResponse(t, res, tt.status, tt.minirouter, "", tt.body)
+			if !strings.Contains(logs.String(), tt.log) || !strings.Contains(logs.String(), "goroutine") {
+				t.Errorf("Wrong log %q", logs.String())
+			}
+		})
+	}
+}
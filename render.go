@@ -0,0 +1,204 @@
+package minirouter
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"html/template"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// DefaultProduces lists the media types Render can respond with, by order of preference, for the routes that declare
+// none with Route.Produces. It must only be modified before requests are served.
+var DefaultProduces = []string{"application/json", "application/xml", "text/plain", "text/html"}
+
+// HTML is a value rendered by Render as text/html by executing a template.
+type HTML struct {
+	Template *template.Template
+	// Name is the name of the template to execute. The Template itself is executed if empty.
+	Name string
+	Data interface{}
+}
+
+// Render writes v with the given status, encoded with the media type preferred by the request's Accept header among
+// the media types produced by the route (see Route.Produces and DefaultProduces) that v can be rendered as:
+//   - JSON (application/json and */*+json) and XML (application/xml, text/xml and */*+xml) for any value but HTML,
+//   - text/plain for strings, []byte, fmt.Stringer and errors,
+//   - text/html for HTML and template.HTML.
+//
+// Quality values are honored (eg. "application/xml;q=0.9, */*;q=0.1"), and requests without Accept header get the
+// first media type. Nothing is written if Render fails, so that its error can be written by an ErrorHandler, eg. by
+// returning it from a HandlerFuncE. When no media type is acceptable, the error has the status 406 Not Acceptable.
+func Render(w http.ResponseWriter, req *http.Request, status int, v interface{}) error {
+	offers := DefaultProduces
+	if route := routeFromRequest(req); route != nil {
+		if produces := route.Info().Doc.Produces; len(produces) > 0 {
+			offers = produces
+		}
+	}
+
+	var renderable []string
+	for _, offer := range offers {
+		if canRender(offer, v) {
+			renderable = append(renderable, offer)
+		}
+	}
+	mediaType := negotiate(req.Header.Get("Accept"), renderable)
+	if mediaType == "" {
+		return NewHTTPError(http.StatusNotAcceptable,
+			fmt.Errorf("none of the available media types is acceptable: %s", strings.Join(renderable, ", ")))
+	}
+
+	b, err := encode(mediaType, v)
+	if err != nil {
+		return err
+	}
+	w.Header().Add("Vary", "Accept")
+	w.Header().Set("Content-Type", mediaType+"; charset=utf-8")
+	w.WriteHeader(status)
+	_, _ = w.Write(b)
+	return nil
+}
+
+// Negotiate returns the media type of offers preferred by the request's Accept header, or an empty string if none is
+// acceptable. Offers are given by order of preference, the first one being returned if the request has no Accept
+// header.
+func Negotiate(req *http.Request, offers ...string) string {
+	return negotiate(req.Header.Get("Accept"), offers)
+}
+
+// mediaRange is a media range of an Accept header, along with its quality value.
+type mediaRange struct {
+	typ, subtype string
+	q            float64
+}
+
+// specificity returns how precisely the range matches mediaType: 2 for an exact match, 1 for "type/*", 0 for "*/*",
+// or -1 if it does not match.
+func (r mediaRange) specificity(mediaType string) int {
+	typ, subtype, _ := strings.Cut(mediaType, "/")
+	switch {
+	case r.typ == "*" && r.subtype == "*":
+		return 0
+	case !strings.EqualFold(r.typ, typ):
+		return -1
+	case r.subtype == "*":
+		return 1
+	case strings.EqualFold(r.subtype, subtype):
+		return 2
+	}
+	return -1
+}
+
+func parseAccept(accept string) []mediaRange {
+	var ranges []mediaRange
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		typ, subtype, ok := strings.Cut(mediaType, "/")
+		if !ok {
+			continue
+		}
+		r := mediaRange{typ: typ, subtype: subtype, q: 1}
+		if q, ok := params["q"]; ok {
+			if r.q, err = strconv.ParseFloat(q, 64); err != nil {
+				continue
+			}
+		}
+		ranges = append(ranges, r)
+	}
+	return ranges
+}
+
+// negotiate returns the offer with the highest quality value in accept, the first one winning ties.
+func negotiate(accept string, offers []string) string {
+	if len(offers) == 0 {
+		return ""
+	}
+	if strings.TrimSpace(accept) == "" {
+		return offers[0]
+	}
+
+	ranges := parseAccept(accept)
+	best, bestQ := "", 0.0
+	for _, offer := range offers {
+		// The quality of an offer is given by the most specific range matching it.
+		q, specificity := 0.0, -1
+		for _, r := range ranges {
+			if s := r.specificity(offer); s > specificity {
+				q, specificity = r.q, s
+			}
+		}
+		if q > bestQ {
+			best, bestQ = offer, q
+		}
+	}
+	return best
+}
+
+func isJSON(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+func isXML(mediaType string) bool {
+	return mediaType == "application/xml" || mediaType == "text/xml" || strings.HasSuffix(mediaType, "+xml")
+}
+
+// canRender reports whether v can be encoded with mediaType.
+func canRender(mediaType string, v interface{}) bool {
+	switch {
+	case isJSON(mediaType), isXML(mediaType):
+		_, isHTML := v.(HTML)
+		return !isHTML
+	case mediaType == "text/plain":
+		switch v.(type) {
+		case string, []byte, fmt.Stringer, error:
+			return true
+		}
+	case mediaType == "text/html":
+		switch v.(type) {
+		case HTML, template.HTML:
+			return true
+		}
+	}
+	return false
+}
+
+// encode encodes v with mediaType, which must be one canRender accepts for v.
+func encode(mediaType string, v interface{}) ([]byte, error) {
+	switch {
+	case isJSON(mediaType):
+		return json.Marshal(v)
+	case isXML(mediaType):
+		return xml.Marshal(v)
+	}
+
+	switch v := v.(type) {
+	case string:
+		return []byte(v), nil
+	case []byte:
+		return v, nil
+	case template.HTML:
+		return []byte(v), nil
+	case HTML:
+		var buf bytes.Buffer
+		var err error
+		if v.Name == "" {
+			err = v.Template.Execute(&buf, v.Data)
+		} else {
+			err = v.Template.ExecuteTemplate(&buf, v.Name, v.Data)
+		}
+		return buf.Bytes(), err
+	case fmt.Stringer:
+		return []byte(v.String()), nil
+	case error:
+		return []byte(v.Error()), nil
+	}
+	return nil, fmt.Errorf("minirouter: cannot render %T as %s", v, mediaType)
+}
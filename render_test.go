@@ -0,0 +1,110 @@
+package minirouter
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNegotiate(t *testing.T) {
+	offers := []string{"application/json", "application/xml", "text/html"}
+	tests := []struct {
+		name   string
+		accept string
+		want   string
+	}{
+		{name: "No Accept header", accept: "", want: "application/json"},
+		{name: "Exact match", accept: "application/xml", want: "application/xml"},
+		{name: "Wildcard", accept: "*/*", want: "application/json"},
+		{name: "Type wildcard", accept: "text/*", want: "text/html"},
+		{name: "Quality values", accept: "application/json;q=0.5, application/xml;q=0.8", want: "application/xml"},
+		{name: "Most specific range wins", accept: "application/*;q=0.1, application/xml, */*;q=0.5", want: "application/xml"},
+		{name: "Browser", accept: "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", want: "text/html"},
+		{name: "Excluded", accept: "application/json;q=0, */*", want: "application/xml"},
+		{name: "Not acceptable", accept: "image/png", want: ""},
+		{name: "Invalid quality value", accept: "application/xml;q=high, text/html", want: "text/html"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			if got := Negotiate(req, offers...); got != tt.want {
+				t.Errorf("Negotiate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	page := template.Must(template.New("page").Parse(`<h1>{{.}}</h1>`))
+
+	r := New()
+	r.HandleFuncE(http.MethodGet, "/user", func(w http.ResponseWriter, req *http.Request) error {
+		return Render(w, req, http.StatusOK, testCreateUser{Name: "john"})
+	})
+	r.HandleFuncE(http.MethodGet, "/text", func(w http.ResponseWriter, req *http.Request) error {
+		return Render(w, req, http.StatusOK, "hello")
+	})
+	r.HandleFuncE(http.MethodGet, "/page", func(w http.ResponseWriter, req *http.Request) error {
+		return Render(w, req, http.StatusOK, HTML{Template: page, Data: "john"})
+	})
+	r.HandleFuncE(http.MethodGet, "/produces", func(w http.ResponseWriter, req *http.Request) error {
+		return Render(w, req, http.StatusCreated, testCreateUser{Name: "john"})
+	}).Produces("application/xml")
+
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	tests := []struct {
+		name        string
+		path        string
+		accept      string
+		status      int
+		contentType string
+		body        string
+	}{
+		{
+			name: "JSON by default", path: "/user", status: 200,
+			contentType: "application/json; charset=utf-8", body: `{"name":"john"}`,
+		}, {
+			name: "XML", path: "/user", accept: "application/xml", status: 200,
+			contentType: "application/xml; charset=utf-8", body: `<testCreateUser><Name>john</Name></testCreateUser>`,
+		}, {
+			name: "Struct as text", path: "/user", accept: "text/plain", status: 406,
+			contentType: "text/plain; charset=utf-8", body: "none of the available media types is acceptable: application/json, application/xml",
+		}, {
+			name: "Text", path: "/text", accept: "text/plain, application/json;q=0.5", status: 200,
+			contentType: "text/plain; charset=utf-8", body: "hello",
+		}, {
+			name: "HTML", path: "/page", accept: "text/html", status: 200,
+			contentType: "text/html; charset=utf-8", body: "<h1>john</h1>",
+		}, {
+			name: "HTML only", path: "/page", status: 200,
+			contentType: "text/html; charset=utf-8", body: "<h1>john</h1>",
+		}, {
+			name: "Produces", path: "/produces", accept: "*/*", status: 201,
+			contentType: "application/xml; charset=utf-8", body: `<testCreateUser><Name>john</Name></testCreateUser>`,
+		}, {
+			name: "Produces not acceptable", path: "/produces", accept: "application/json", status: 406,
+			contentType: "text/plain; charset=utf-8", body: "none of the available media types is acceptable: application/xml",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, srv.URL+tt.path, nil)
+			assertNoError(t, err)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			res, err := http.DefaultClient.Do(req)
+			assertNoError(t, err)
+			if ct := res.Header.Get("Content-Type"); ct != tt.contentType {
+				t.Errorf("Wrong content type %s", ct)
+			}
+			assertResponse(t, res, tt.status, "", "", tt.body)
+		})
+	}
+}
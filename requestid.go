@@ -0,0 +1,121 @@
+package minirouter
+
+import (
+	"context"
+	"crypto/rand"
+	"net/http"
+)
+
+// RequestIDHeader is the default header carrying request IDs.
+const RequestIDHeader = "X-Request-ID"
+
+// RequestIDOptions configures the middleware returned by RequestIDMiddleware.
+type RequestIDOptions struct {
+	// Header is the header carrying the request ID. Defaults to RequestIDHeader.
+	Header string
+	// Generate returns a new request ID. Defaults to a random UUID.
+	Generate func() string
+}
+
+type requestIDKey struct{}
+
+// RequestIDMiddleware returns a Middleware giving an ID to each request: the ID found in the request's header, or a
+// new one if there is none. The ID is stored in the request's context, where it can be read with RequestID, and is
+// echoed in the response's header. Incoming IDs longer than 200 characters or containing non-printable characters are
+// replaced, so that they can safely be logged.
+// Use RequestIDTransport to forward the ID to the services called while handling the request.
+func RequestIDMiddleware(options RequestIDOptions) Middleware {
+	header := options.Header
+	if header == "" {
+		header = RequestIDHeader
+	}
+	generate := options.Generate
+	if generate == nil {
+		generate = newRequestID
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			id := req.Header.Get(header)
+			if !validRequestID(id) {
+				id = generate()
+			}
+			w.Header().Set(header, id)
+			next.ServeHTTP(w, req.WithContext(ContextWithRequestID(req.Context(), id)))
+		})
+	}
+}
+
+// RequestID returns the ID of the request given by RequestIDMiddleware, or an empty string if it has none.
+func RequestID(req *http.Request) string {
+	return RequestIDFromContext(req.Context())
+}
+
+// RequestIDFromContext returns the request ID stored in ctx, or an empty string if there is none.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// ContextWithRequestID returns a copy of ctx storing the request ID id, eg. to propagate the ID of a request to a
+// background job.
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+func validRequestID(id string) bool {
+	if id == "" || len(id) > 200 {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// newRequestID returns a random (version 4) UUID.
+func newRequestID() string {
+	var u UUID
+	if _, err := rand.Read(u[:]); err != nil {
+		panic("minirouter: cannot generate a request ID: " + err.Error())
+	}
+	u[6] = u[6]&0x0f | 0x40
+	u[8] = u[8]&0x3f | 0x80
+	return u.String()
+}
+
+// RequestIDTransport is an http.RoundTripper forwarding the request ID stored in the context of outgoing requests
+// (see RequestIDFromContext), so that the calls made while handling a request can be correlated with it, eg.
+//
+//	client := &http.Client{Transport: &minirouter.RequestIDTransport{}}
+//	req, _ := http.NewRequestWithContext(incoming.Context(), http.MethodGet, url, nil)
+//	res, err := client.Do(req)
+type RequestIDTransport struct {
+	// Base is the RoundTripper sending the requests. Defaults to http.DefaultTransport.
+	Base http.RoundTripper
+	// Header is the header carrying the request ID. Defaults to RequestIDHeader.
+	Header string
+}
+
+// RoundTrip sets the request ID header of req, unless it is already set, and sends req with the Base RoundTripper.
+func (t *RequestIDTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	header := t.Header
+	if header == "" {
+		header = RequestIDHeader
+	}
+
+	id := RequestIDFromContext(req.Context())
+	if id == "" || req.Header.Get(header) != "" {
+		return base.RoundTrip(req)
+	}
+	// A RoundTripper must not modify the request.
+	r2 := req.Clone(req.Context())
+	r2.Header.Set(header, id)
+	return base.RoundTrip(r2)
+}
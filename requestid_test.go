@@ -0,0 +1,96 @@
+package minirouter
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	echo := func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(RequestID(r)))
+	}
+
+	r := New()
+	r.Use(RequestIDMiddleware(RequestIDOptions{}))
+	r.GET("/default", echo)
+	custom := New()
+	custom.Use(RequestIDMiddleware(RequestIDOptions{Header: "X-Correlation-ID", Generate: func() string { return "generated" }}))
+	custom.GET("/custom", echo)
+
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+	customSrv := httptest.NewServer(custom)
+	defer customSrv.Close()
+
+	uuidV4 := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	tests := []struct {
+		name     string
+		url      string
+		header   string
+		incoming string
+		want     string
+	}{
+		{name: "Generated", url: srv.URL + "/default", header: RequestIDHeader},
+		{name: "Incoming", url: srv.URL + "/default", header: RequestIDHeader, incoming: "abc-123", want: "abc-123"},
+		{name: "Invalid incoming", url: srv.URL + "/default", header: RequestIDHeader, incoming: strings.Repeat("a", 201)},
+		{name: "Custom header", url: customSrv.URL + "/custom", header: "X-Correlation-ID", incoming: "abc-123", want: "abc-123"},
+		{name: "Custom generator", url: customSrv.URL + "/custom", header: "X-Correlation-ID", want: "generated"},
+		{name: "Custom generator, unmatched route", url: customSrv.URL + "/unknown", header: "X-Correlation-ID", want: "generated"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			assertNoError(t, err)
+			if tt.incoming != "" {
+				req.Header.Set(tt.header, tt.incoming)
+			}
+			res, err := http.DefaultClient.Do(req)
+			assertNoError(t, err)
+			id := res.Header.Get(tt.header)
+			if tt.want == "" && !uuidV4.MatchString(id) || tt.want != "" && id != tt.want {
+				t.Errorf("Wrong request ID %q", id)
+			}
+			if res.StatusCode == http.StatusOK {
+				assertResponse(t, res, 200, "", "", id)
+			} else {
+				res.Body.Close()
+			}
+		})
+	}
+}
+
+func TestRequestIDTransport(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Header.Get("X-Correlation-ID")))
+	}))
+	defer upstream.Close()
+
+	client := &http.Client{Transport: &RequestIDTransport{Header: "X-Correlation-ID"}}
+	r := New()
+	r.Use(RequestIDMiddleware(RequestIDOptions{Header: "X-Correlation-ID"}))
+	r.GET("/proxy", func(w http.ResponseWriter, r *http.Request) {
+		req, _ := http.NewRequestWithContext(r.Context(), http.MethodGet, upstream.URL, nil)
+		res, err := client.Do(req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		defer res.Body.Close()
+		w.WriteHeader(res.StatusCode)
+		_, _ = io.Copy(w, res.Body)
+	})
+
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/proxy", nil)
+	assertNoError(t, err)
+	req.Header.Set("X-Correlation-ID", "abc-123")
+	res, err := http.DefaultClient.Do(req)
+	assertNoError(t, err)
+	assertResponse(t, res, 200, "", "", "abc-123")
+}
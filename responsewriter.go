@@ -0,0 +1,159 @@
+package minirouter
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+)
+
+// ResponseWriter is an http.ResponseWriter recording the status code and the size of the response, so that
+// middlewares can see them once the handler returns (see NewResponseWriter).
+type ResponseWriter interface {
+	http.ResponseWriter
+	// Status returns the status code of the response, or 0 if it has not been written yet.
+	Status() int
+	// BytesWritten returns the number of bytes of the response's body written so far.
+	BytesWritten() int64
+	// Written reports whether the response has been started, in which case its status code can no longer be changed.
+	Written() bool
+	// Unwrap returns the wrapped http.ResponseWriter, for http.ResponseController.
+	Unwrap() http.ResponseWriter
+}
+
+// NewResponseWriter wraps w into a ResponseWriter. The returned ResponseWriter implements http.Flusher, http.Hijacker
+// and io.ReaderFrom only if w does, so that handlers can keep detecting these capabilities.
+// If w already is a ResponseWriter, it is returned as is, so that nested middlewares share the same records.
+func NewResponseWriter(w http.ResponseWriter) ResponseWriter {
+	if rw, ok := w.(ResponseWriter); ok {
+		return rw
+	}
+
+	rw := &responseWriter{ResponseWriter: w}
+	_, isFlusher := w.(http.Flusher)
+	_, isHijacker := w.(http.Hijacker)
+	_, isReaderFrom := w.(io.ReaderFrom)
+	switch {
+	case isFlusher && isHijacker && isReaderFrom:
+		return struct {
+			*responseWriter
+			flusher
+			hijacker
+			readerFrom
+		}{rw, flusher{rw}, hijacker{rw}, readerFrom{rw}}
+	case isFlusher && isHijacker:
+		return struct {
+			*responseWriter
+			flusher
+			hijacker
+		}{rw, flusher{rw}, hijacker{rw}}
+	case isFlusher && isReaderFrom:
+		return struct {
+			*responseWriter
+			flusher
+			readerFrom
+		}{rw, flusher{rw}, readerFrom{rw}}
+	case isHijacker && isReaderFrom:
+		return struct {
+			*responseWriter
+			hijacker
+			readerFrom
+		}{rw, hijacker{rw}, readerFrom{rw}}
+	case isFlusher:
+		return struct {
+			*responseWriter
+			flusher
+		}{rw, flusher{rw}}
+	case isHijacker:
+		return struct {
+			*responseWriter
+			hijacker
+		}{rw, hijacker{rw}}
+	case isReaderFrom:
+		return struct {
+			*responseWriter
+			readerFrom
+		}{rw, readerFrom{rw}}
+	}
+	return rw
+}
+
+// responseWriter implements ResponseWriter. The optional interfaces are implemented by flusher, hijacker and
+// readerFrom, which are only embedded next to it when the wrapped http.ResponseWriter implements them.
+type responseWriter struct {
+	http.ResponseWriter
+	status      int
+	written     int64
+	wroteHeader bool
+}
+
+func (w *responseWriter) Status() int {
+	return w.status
+}
+
+func (w *responseWriter) BytesWritten() int64 {
+	return w.written
+}
+
+func (w *responseWriter) Written() bool {
+	return w.wroteHeader
+}
+
+// WriteHeader records the status code. Informational (1xx) status codes are passed through without being recorded.
+func (w *responseWriter) WriteHeader(status int) {
+	if !w.wroteHeader && status >= 200 {
+		w.status, w.wroteHeader = status, true
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	w.start()
+	n, err := w.ResponseWriter.Write(b)
+	w.written += int64(n)
+	return n, err
+}
+
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// start records the implicit 200 status code of responses written without calling WriteHeader.
+func (w *responseWriter) start() {
+	if !w.wroteHeader {
+		w.status, w.wroteHeader = http.StatusOK, true
+	}
+}
+
+type flusher struct {
+	rw *responseWriter
+}
+
+func (f flusher) Flush() {
+	f.rw.start()
+	f.rw.ResponseWriter.(http.Flusher).Flush()
+}
+
+type hijacker struct {
+	rw *responseWriter
+}
+
+func (h hijacker) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, brw, err := h.rw.ResponseWriter.(http.Hijacker).Hijack()
+	if err == nil && !h.rw.wroteHeader {
+		h.rw.status, h.rw.wroteHeader = http.StatusSwitchingProtocols, true
+	}
+	return conn, brw, err
+}
+
+// readerFrom lets the wrapped http.ResponseWriter use sendfile.
+type readerFrom struct {
+	rw *responseWriter
+}
+
+func (r readerFrom) ReadFrom(src io.Reader) (int64, error) {
+	r.rw.start()
+	n, err := r.rw.ResponseWriter.(io.ReaderFrom).ReadFrom(src)
+	r.rw.written += n
+	return n, err
+}
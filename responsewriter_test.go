@@ -0,0 +1,66 @@
+package minirouter
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// plainWriter is an http.ResponseWriter implementing none of the optional interfaces.
+type plainWriter struct {
+	http.ResponseWriter
+}
+
+func TestNewResponseWriter(t *testing.T) {
+	t.Run("Optional interfaces of the wrapped writer", func(t *testing.T) {
+		rw := NewResponseWriter(httptest.NewRecorder())
+		if _, ok := rw.(http.Flusher); !ok {
+			t.Error("Expected the ResponseWriter to implement http.Flusher like httptest.ResponseRecorder")
+		}
+		if _, ok := rw.(http.Hijacker); ok {
+			t.Error("Expected the ResponseWriter not to implement http.Hijacker")
+		}
+		if _, ok := rw.(io.ReaderFrom); ok {
+			t.Error("Expected the ResponseWriter not to implement io.ReaderFrom")
+		}
+
+		rw = NewResponseWriter(plainWriter{httptest.NewRecorder()})
+		if _, ok := rw.(http.Flusher); ok {
+			t.Error("Expected the ResponseWriter not to implement http.Flusher")
+		}
+	})
+
+	t.Run("Records through the optional interfaces", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		rw := NewResponseWriter(rec)
+		rw.(http.Flusher).Flush()
+		_, _ = io.Copy(rw, strings.NewReader("hello"))
+		if rw.Status() != http.StatusOK || rw.BytesWritten() != 5 || !rw.Written() {
+			t.Errorf("Expected status 200 and 5 bytes written, got %d and %d", rw.Status(), rw.BytesWritten())
+		}
+		if NewResponseWriter(rw) != rw {
+			t.Error("Expected an existing ResponseWriter to be reused")
+		}
+		if rw.Unwrap() != rec {
+			t.Error("Expected Unwrap to return the wrapped writer")
+		}
+	})
+
+	t.Run("Server writer", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rw := NewResponseWriter(w)
+			_, isFlusher := rw.(http.Flusher)
+			_, isHijacker := rw.(http.Hijacker)
+			_, isReaderFrom := rw.(io.ReaderFrom)
+			if !isFlusher || !isHijacker || !isReaderFrom {
+				http.Error(w, "missing interface", http.StatusInternalServerError)
+			}
+		}))
+		defer srv.Close()
+		res, err := http.Get(srv.URL)
+		assertNoError(t, err)
+		assertResponse(t, res, 200, "", "", "")
+	})
+}
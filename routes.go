@@ -0,0 +1,317 @@
+package minirouter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// RouteInfo describes a route registered on a Mini.
+type RouteInfo struct {
+	// Method is the HTTP method of the route.
+	Method string
+	// Path is the full path of the route, including the base-path of the group it was registered on.
+	Path string
+	// BasePath is the base-path of the group the route was registered on.
+	BasePath string
+	// Middlewares is the middleware chain wrapping the route's handler, outermost first.
+	// It contains the group's middlewares followed by the route's inline middlewares.
+	Middlewares []Middleware
+	// Source is the location (file:line) of the code that registered the route.
+	Source string
+	// Name is the name given to the route with Route.Name, if any.
+	Name string
+	// Doc holds the documentation attached to the route, used to generate OpenAPI documents.
+	Doc RouteDoc
+}
+
+// RouteDoc is the documentation attached to a route.
+type RouteDoc struct {
+	Summary     string
+	Description string
+	Tags        []string
+	// Request is the type of the request, if any. For structs bound with Bind, the fields tagged with `query` or
+	// `header` are documented as parameters, and only the remaining fields as the request body. For form media types
+	// (see Consumes), the request body is made of the fields tagged with `form`.
+	Request reflect.Type
+	// Responses maps status codes to the type of the response body (nil if there is no body).
+	Responses map[int]reflect.Type
+	// Produces lists the media types the route responds with, by order of preference (see Render).
+	Produces []string
+	// Consumes lists the media types of the request bodies accepted by the route.
+	Consumes []string
+}
+
+// Route is a route registered on a Mini. It is returned by Handle (and its helpers) so that extra information can be
+// attached to the route after its registration.
+type Route struct {
+	reg  *registry
+	info RouteInfo
+
+	errorHandler ErrorHandler
+}
+
+type routeKey struct{}
+
+// routeHolder is stored in the request's context before the global middlewares run, so that they can find the route
+// matched by the request once it has been routed.
+// For the requests served by a sub-application mounted with MountMini, the holder of the sub-application has the
+// holder of the parent as outer, which gets the route as seen from the parent (see mountedMini.adaptRoute).
+type routeHolder struct {
+	route *Route
+	outer *routeHolder
+	adapt func(route *Route) *Route
+}
+
+// set records route as the route matched by the request.
+func (h *routeHolder) set(route *Route) {
+	h.route = route
+	if h.outer != nil {
+		h.outer.set(h.adapt(route))
+	}
+}
+
+type routeHolderKey struct{}
+
+// withRoute returns a handler serving the request to handler with route stored in the request's context.
+func withRoute(route *Route, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if holder, ok := req.Context().Value(routeHolderKey{}).(*routeHolder); ok {
+			holder.set(route)
+		}
+		handler.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), routeKey{}, route)))
+	})
+}
+
+// routeFromRequest returns the route matched by the request, or nil if the request has not been routed yet.
+// Global middlewares (see Mini.Use) get the route once the request has been served.
+func routeFromRequest(req *http.Request) *Route {
+	if route, ok := req.Context().Value(routeKey{}).(*Route); ok {
+		return route
+	}
+	if holder, ok := req.Context().Value(routeHolderKey{}).(*routeHolder); ok {
+		return holder.route
+	}
+	return nil
+}
+
+// Name gives a name to the route, so that its URL can be built with Mini.URL.
+// It panics if the name is already used by another route.
+func (r *Route) Name(name string) *Route {
+	r.reg.setName(r, name)
+	return r
+}
+
+// Summary sets a short summary of what the route does.
+func (r *Route) Summary(summary string) *Route {
+	r.reg.mu.Lock()
+	defer r.reg.mu.Unlock()
+	r.info.Doc.Summary = summary
+	return r
+}
+
+// Description sets a verbose explanation of the route's behavior.
+func (r *Route) Description(description string) *Route {
+	r.reg.mu.Lock()
+	defer r.reg.mu.Unlock()
+	r.info.Doc.Description = description
+	return r
+}
+
+// Tags adds tags to the route, used to group routes together in the documentation.
+func (r *Route) Tags(tags ...string) *Route {
+	r.reg.mu.Lock()
+	defer r.reg.mu.Unlock()
+	r.info.Doc.Tags = append(r.info.Doc.Tags, tags...)
+	return r
+}
+
+// Request documents the type of the request with a sample value, eg. Request(CreateUser{}). For structs bound with
+// Bind, the fields tagged with `query` or `header` are documented as parameters, and the remaining fields as the
+// request body.
+func (r *Route) Request(body interface{}) *Route {
+	r.reg.mu.Lock()
+	defer r.reg.mu.Unlock()
+	r.info.Doc.Request = reflect.TypeOf(body)
+	return r
+}
+
+// Response documents the type of the response body for the given status code with a sample value,
+// eg. Response(http.StatusOK, User{}). A nil body documents a response without body.
+func (r *Route) Response(status int, body interface{}) *Route {
+	r.reg.mu.Lock()
+	defer r.reg.mu.Unlock()
+	// Copy the map so that RouteInfo values previously returned by Info or Routes are left untouched.
+	responses := make(map[int]reflect.Type, len(r.info.Doc.Responses)+1)
+	for code, typ := range r.info.Doc.Responses {
+		responses[code] = typ
+	}
+	responses[status] = reflect.TypeOf(body)
+	r.info.Doc.Responses = responses
+	return r
+}
+
+// Produces declares the media types the route responds with, by order of preference, eg. Produces("application/json",
+// "text/html"). They are the media types Render negotiates with the Accept header, and the media types of the
+// responses in the OpenAPI document.
+func (r *Route) Produces(mediaTypes ...string) *Route {
+	r.reg.mu.Lock()
+	defer r.reg.mu.Unlock()
+	r.info.Doc.Produces = append([]string(nil), mediaTypes...)
+	return r
+}
+
+// Consumes declares the media types of the request bodies accepted by the route, eg. Consumes("application/json").
+// Ranges such as "text/*" are allowed. Requests having a body with another Content-Type are rejected with a
+// *UnsupportedMediaTypeError (415), malformed JSON bodies with a *BindError (400), and JSON bodies larger than
+// MaxJSONBodySize with 413. The check happens right before the route's handler, so these replies still go through the
+// route's middlewares. They are also the media types of the request body in the OpenAPI document.
+func (r *Route) Consumes(mediaTypes ...string) *Route {
+	r.reg.mu.Lock()
+	defer r.reg.mu.Unlock()
+	r.info.Doc.Consumes = append([]string(nil), mediaTypes...)
+	return r
+}
+
+// Info returns a description of the route.
+func (r *Route) Info() RouteInfo {
+	r.reg.mu.RLock()
+	defer r.reg.mu.RUnlock()
+	return r.info
+}
+
+// registry keeps track of all the routes registered on a Mini and its copies.
+type registry struct {
+	mu     sync.RWMutex
+	routes []*Route
+	names  map[string]*Route
+
+	mounts []mountedMini
+
+	entries     []*routeEntry
+	overlays    map[string]int // Number of overlay routes per method.
+	constrained int            // Number of routes having constrained parameters.
+
+	collectConflicts bool
+	conflicts        RouteErrors
+
+	notFound         []fallback
+	methodNotAllowed []fallback
+
+	pre      []Middleware
+	global   []Middleware
+	dispatch http.Handler // The router wrapped with the pre-routing and global middlewares, if any.
+}
+
+func (reg *registry) add(route *Route) {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	reg.routes = append(reg.routes, route)
+}
+
+func (reg *registry) setName(route *Route, name string) {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	if other, ok := reg.names[name]; ok && other != route {
+		panic(fmt.Sprintf("minirouter: route name '%s' is already used by %s %s", name, other.info.Method, other.info.Path))
+	}
+	if reg.names == nil {
+		reg.names = make(map[string]*Route)
+	}
+	delete(reg.names, route.info.Name)
+	route.info.Name = name
+	reg.names[name] = route
+}
+
+func (reg *registry) lookup(name string) (RouteInfo, bool) {
+	reg.mu.RLock()
+	defer reg.mu.RUnlock()
+	if route, ok := reg.names[name]; ok {
+		return route.info, true
+	}
+	for _, mounted := range reg.mounts {
+		if info, ok := mounted.sub.lookup(name); ok && mounted.methods[info.Method] {
+			return mounted.adapt(info), true
+		}
+	}
+	return RouteInfo{}, false
+}
+
+func (reg *registry) list() []RouteInfo {
+	reg.mu.RLock()
+	defer reg.mu.RUnlock()
+	routes := make([]RouteInfo, len(reg.routes))
+	for i, route := range reg.routes {
+		routes[i] = route.info
+	}
+	for _, mounted := range reg.mounts {
+		for _, info := range mounted.sub.list() {
+			if mounted.methods[info.Method] {
+				routes = append(routes, mounted.adapt(info))
+			}
+		}
+	}
+	return routes
+}
+
+// Routes returns all the routes registered so far, in registration order, followed by the routes of the mounted
+// sub-applications (see MountMini).
+// The registry is shared by all the copies of a Mini (see WithBasePath and WithMiddleware), so calling Routes on any of
+// them returns the same list.
+func (m *Mini) Routes() []RouteInfo {
+	return m.routes.list()
+}
+
+// URL builds the path of the route with the given name, replacing its parameters with the given values.
+// Values are given as name/value pairs, eg. URL("user", "id", "42") for a route "/users/:id", and must satisfy the
+// constraints of their parameter, if any.
+// Since routes are registered with their full path, the base-path of the group they belong to is always included.
+func (m *Mini) URL(name string, params ...string) (string, error) {
+	if len(params)%2 != 0 {
+		return "", errors.New("minirouter: URL params must be name/value pairs")
+	}
+	route, ok := m.routes.lookup(name)
+	if !ok {
+		return "", fmt.Errorf("minirouter: no route named '%s'", name)
+	}
+
+	values := make(map[string]string, len(params)/2)
+	for i := 0; i < len(params); i += 2 {
+		values[params[i]] = params[i+1]
+	}
+
+	p, err := parsePattern(route.Path)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	for _, s := range p.segments {
+		b.WriteString("/" + s.prefix)
+		if s.kind == staticSegment {
+			continue
+		}
+		value, ok := values[s.name]
+		if !ok {
+			return "", fmt.Errorf("minirouter: missing value for parameter '%s' of route '%s'", s.name, name)
+		}
+		if s.kind != catchAllSegment {
+			if !s.matches(value) {
+				return "", fmt.Errorf("minirouter: value '%s' does not satisfy the constraint of parameter '%s' of route '%s'", value, s.name, name)
+			}
+			b.WriteString(url.PathEscape(value))
+			continue
+		}
+		// Catch-all parameters may span several segments, and httprouter gives them a leading '/'.
+		parts := strings.Split(strings.TrimPrefix(value, "/"), "/")
+		for i, part := range parts {
+			parts[i] = url.PathEscape(part)
+		}
+		b.WriteString(strings.Join(parts, "/"))
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,92 @@
+package minirouter
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestMini_Routes(t *testing.T) {
+	noop := func(w http.ResponseWriter, r *http.Request) {}
+	mw := func(next http.Handler) http.Handler { return next }
+
+	r := New()
+	r.GET("/", noop)
+
+	g := r.WithBasePath("/admin").WithMiddleware(mw)
+	g.POST("/users", noop)
+	g.GET("/users/:id", noop, mw)
+
+	tests := []struct {
+		method      string
+		path        string
+		basePath    string
+		middlewares int
+	}{
+		{method: http.MethodGet, path: "/", basePath: "", middlewares: 0},
+		{method: http.MethodPost, path: "/admin/users", basePath: "/admin", middlewares: 1},
+		{method: http.MethodGet, path: "/admin/users/:id", basePath: "/admin", middlewares: 2},
+	}
+
+	for _, m := range []*Mini{r, g} {
+		routes := m.Routes()
+		if len(routes) != len(tests) {
+			t.Fatalf("Wrong number of routes. Expected %d, got %d", len(tests), len(routes))
+		}
+		for i, tt := range tests {
+			got := routes[i]
+			if got.Method != tt.method || got.Path != tt.path || got.BasePath != tt.basePath || len(got.Middlewares) != tt.middlewares {
+				t.Errorf("Wrong route #%d. Expected %s %s (base-path %q, %d middlewares), got %s %s (base-path %q, %d middlewares)",
+					i, tt.method, tt.path, tt.basePath, tt.middlewares, got.Method, got.Path, got.BasePath, len(got.Middlewares))
+			}
+		}
+	}
+}
+
+func TestMini_URL(t *testing.T) {
+	noop := func(w http.ResponseWriter, r *http.Request) {}
+
+	r := New()
+	r.GET("/", noop).Name("index")
+	g := r.WithBasePath("/admin")
+	g.GET("/users/:id", noop).Name("user")
+	g.GET("/users/:id/files/*filepath", noop).Name("user-file")
+	g.GET("/orders/{id:[0-9]+}", noop).Name("order")
+
+	tests := []struct {
+		name    string
+		route   string
+		params  []string
+		want    string
+		wantErr bool
+	}{
+		{name: "No param", route: "index", want: "/"},
+		{name: "Some param with base-path", route: "user", params: []string{"id", "42"}, want: "/admin/users/42"},
+		{name: "Escaped param", route: "user", params: []string{"id", "a b/c"}, want: "/admin/users/a%20b%2Fc"},
+		{name: "Catch-all param", route: "user-file", params: []string{"id", "42", "filepath", "/a/b c"}, want: "/admin/users/42/files/a/b%20c"},
+		{name: "Constrained param", route: "order", params: []string{"id", "42"}, want: "/admin/orders/42"},
+		{name: "Unsatisfied constraint", route: "order", params: []string{"id", "abc"}, wantErr: true},
+		{name: "Missing param", route: "user", wantErr: true},
+		{name: "Odd params", route: "user", params: []string{"id"}, wantErr: true},
+		{name: "Unknown route", route: "unknown", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := g.URL(tt.route, tt.params...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("URL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("URL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("Duplicate name", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected a panic")
+			}
+		}()
+		r.GET("/other", noop).Name("index")
+	})
+}
@@ -0,0 +1,70 @@
+package minirouter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+)
+
+// RegisterFunc is the signature of the registration helpers of Mini (GET, POST, PUT...), so that they can be passed
+// to JSON as method values, eg. JSON(m.POST, "/users", createUser).
+type RegisterFunc func(path string, handler http.HandlerFunc, middleware ...Middleware) *Route
+
+// JSON registers a typed handler with register, which is one of the registration helpers of a Mini (eg. m.POST).
+//
+// The input of fn is bound from the request with Bind (so it is validated as well), and its output is written with
+// Render according to the Accept header. The response status is 200, unless the output has a StatusCode() int
+// method. Errors are written by the ErrorHandler of the Mini register belongs to.
+//
+// The types of the input and output are attached to the route's documentation (see Route.Request and
+// Route.Response), so they are part of the generated OpenAPI document: the fields of the input bound from the query
+// string and the headers are documented as parameters, and the other fields, if any, as the request body.
+func JSON[In, Out any](
+	register RegisterFunc, path string, fn func(ctx context.Context, in In) (Out, error), middleware ...Middleware,
+) *Route {
+	route := register(path, func(w http.ResponseWriter, req *http.Request) {
+		var in In
+		if err := bindInput(req, &in); err != nil {
+			handleError(w, req, err)
+			return
+		}
+
+		out, err := fn(req.Context(), in)
+		if err != nil {
+			handleError(w, req, err)
+			return
+		}
+
+		status := http.StatusOK
+		if sc, ok := any(out).(interface{ StatusCode() int }); ok {
+			status = sc.StatusCode()
+		}
+		if err := Render(w, req, status, out); err != nil {
+			handleError(w, req, err)
+		}
+	}, middleware...)
+
+	// Structs are documented whatever the method, for their query and header parameters (see Bind). Other types are
+	// only decoded from the body, which these methods do not have.
+	switch route.Info().Method {
+	case http.MethodGet, http.MethodHead, http.MethodDelete, http.MethodOptions:
+		if reflect.TypeOf((*In)(nil)).Elem().Kind() == reflect.Struct {
+			route.Request(*new(In))
+		}
+	default:
+		route.Request(*new(In))
+	}
+	return route.Response(http.StatusOK, *new(Out))
+}
+
+// bindInput binds the request to in with Bind if it is a struct, or decodes the JSON body into it otherwise.
+func bindInput(req *http.Request, in interface{}) error {
+	if reflect.TypeOf(in).Elem().Kind() == reflect.Struct {
+		return Bind(req, in)
+	}
+	if err := json.NewDecoder(req.Body).Decode(in); err != nil && req.ContentLength != 0 {
+		return &BindError{Source: "body", Err: err}
+	}
+	return nil
+}
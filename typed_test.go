@@ -0,0 +1,104 @@
+package minirouter
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type testGetUser struct {
+	ID int `path:"id"`
+}
+
+type testCreated struct {
+	ID   int    `json:"id" xml:"id"`
+	Name string `json:"name" xml:"name"`
+}
+
+func (testCreated) StatusCode() int {
+	return http.StatusCreated
+}
+
+func TestJSON(t *testing.T) {
+	r := New().WithErrorHandler(ProblemErrorHandler)
+	JSON(r.GET, "/users/:id", func(ctx context.Context, in testGetUser) (testUser, error) {
+		if in.ID == 404 {
+			return testUser{}, NewHTTPError(http.StatusNotFound, errors.New("no such user"))
+		}
+		return testUser{ID: "42", Name: "john"}, nil
+	})
+	route := JSON(r.POST, "/users", func(ctx context.Context, in testSignup) (testCreated, error) {
+		return testCreated{ID: 1, Name: in.Name}, nil
+	})
+
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		body        string
+		accept      string
+		status      int
+		contentType string
+		response    string
+	}{
+		{
+			name: "GET", method: http.MethodGet, path: "/users/42", status: 200,
+			contentType: "application/json; charset=utf-8",
+			response:    `{"id":"42","name":"john","createdAt":"0001-01-01T00:00:00Z"}`,
+		}, {
+			name: "Error", method: http.MethodGet, path: "/users/404", status: 404,
+			contentType: ProblemContentType,
+			response:    `{"title":"Not Found","status":404,"detail":"no such user"}`,
+		}, {
+			name: "Invalid input", method: http.MethodGet, path: "/users/john", status: 400,
+			contentType: ProblemContentType,
+		}, {
+			name: "POST", method: http.MethodPost, path: "/users", body: `{"name":"john","email":"john@example.com","address":{"city":"Paris"}}`,
+			status: 201, contentType: "application/json; charset=utf-8",
+			response: `{"id":1,"name":"john"}`,
+		}, {
+			name: "POST XML", method: http.MethodPost, path: "/users", body: `{"name":"john","email":"john@example.com","address":{"city":"Paris"}}`,
+			accept: "application/xml", status: 201, contentType: "application/xml; charset=utf-8",
+			response: `<testCreated><id>1</id><name>john</name></testCreated>`,
+		}, {
+			name: "POST invalid", method: http.MethodPost, path: "/users", body: `{"name":"jo"}`,
+			status: 400, contentType: ProblemContentType,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, srv.URL+tt.path, strings.NewReader(tt.body))
+			assertNoError(t, err)
+			if tt.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			res, err := http.DefaultClient.Do(req)
+			assertNoError(t, err)
+			if ct := res.Header.Get("Content-Type"); ct != tt.contentType {
+				t.Errorf("Wrong content type %s", ct)
+			}
+			if tt.response == "" {
+				res.Body.Close()
+				if res.StatusCode != tt.status {
+					t.Errorf("Wrong status code. Expected %d, got %d", tt.status, res.StatusCode)
+				}
+				return
+			}
+			assertResponse(t, res, tt.status, "", "", tt.response)
+		})
+	}
+
+	doc := route.Info().Doc
+	if doc.Request == nil || doc.Request.Name() != "testSignup" || doc.Responses[http.StatusOK].Name() != "testCreated" {
+		t.Errorf("Wrong route documentation %+v", doc)
+	}
+}
@@ -0,0 +1,285 @@
+package minirouter
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// FieldError describes why the value of a field is invalid.
+type FieldError struct {
+	// Field is the path of the field, using the JSON names of the fields when they have one (eg. "address.city" or
+	// "items[2].name").
+	Field string `json:"field"`
+	// Rule is the validation rule the value does not satisfy (eg. "required" or "max").
+	Rule string `json:"rule"`
+	// Reason is a human-readable explanation.
+	Reason string `json:"reason"`
+}
+
+// ValidationError is the error returned by ValidateStruct, listing all the invalid fields.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	reasons := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		reasons[i] = f.Field + ": " + f.Reason
+	}
+	return "validation failed: " + strings.Join(reasons, "; ")
+}
+
+// StatusCode returns http.StatusBadRequest.
+func (e *ValidationError) StatusCode() int {
+	return http.StatusBadRequest
+}
+
+// Problem converts the error into a Problem, listing the invalid fields in its "errors" extension member.
+func (e *ValidationError) Problem() *Problem {
+	p := NewProblem(http.StatusBadRequest, "The request is invalid.")
+	p.Extensions = map[string]interface{}{"errors": e.Fields}
+	return p
+}
+
+// ValidateStruct validates the struct pointed to by v (or v itself if it is a struct) against the rules given in the
+// `validate` tags of its fields, eg. `validate:"required,min=3,max=32"`. Supported rules are:
+//   - required: the value must not be the zero value (nor a nil pointer),
+//   - omitempty: the other rules are not checked if the value is the zero value (or a nil pointer), so that optional
+//     fields can be left empty,
+//   - min=n, max=n: the value must be at least/at most n for numbers, or its length for strings, slices and maps,
+//   - len=n: the length of the string, slice or map must be exactly n,
+//   - oneof=a b c: the value must be one of the space-separated values,
+//   - email: the value must be an email address,
+//   - pattern=regexp: the string must match the regular expression. Since the expression may contain commas, this
+//     rule must be the last one of the tag.
+//
+// Without omitempty, the rules are checked for zero values as well, eg. min=1 rejects 0, and nil pointers are checked
+// like the zero value they point to.
+// Nested structs, and slices of structs, are validated too.
+// It returns a *ValidationError listing all the invalid fields, or nil.
+func ValidateStruct(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return errors.New("minirouter: ValidateStruct expects a struct")
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return errors.New("minirouter: ValidateStruct expects a struct")
+	}
+
+	var fields []FieldError
+	validateStruct(rv, "", &fields)
+	if len(fields) > 0 {
+		return &ValidationError{Fields: fields}
+	}
+	return nil
+}
+
+func validateStruct(v reflect.Value, prefix string, errs *[]FieldError) {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+		fv := v.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			validateStruct(fv, prefix, errs)
+			continue
+		}
+
+		name, _ := parseTag(field.Tag.Get("json"))
+		if name == "" || name == "-" {
+			name = field.Name
+		}
+		name = prefix + name
+
+		if tag := field.Tag.Get("validate"); tag != "" {
+			validateField(fv, name, tag, errs)
+		}
+		validateNested(fv, name, errs)
+	}
+}
+
+// validateNested validates the structs contained in v.
+func validateNested(v reflect.Value, name string, errs *[]FieldError) {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		if v.Type() != timeType {
+			validateStruct(v, name+".", errs)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			validateNested(v.Index(i), fmt.Sprintf("%s[%d]", name, i), errs)
+		}
+	}
+}
+
+func validateField(v reflect.Value, name, tag string, errs *[]FieldError) {
+	rules := splitRules(tag)
+	for _, rule := range rules {
+		if rule == "omitempty" && isEmpty(v) {
+			return
+		}
+	}
+
+	value := indirect(v)
+	if value.Kind() == reflect.Ptr {
+		// Nil pointers are checked like the zero value they point to.
+		value = reflect.Zero(value.Type().Elem())
+	}
+	for _, rule := range rules {
+		ruleName, arg := rule, ""
+		if i := strings.Index(rule, "="); i >= 0 {
+			ruleName, arg = rule[:i], rule[i+1:]
+		}
+
+		switch ruleName {
+		case "omitempty":
+			continue
+		case "required":
+			if isEmpty(v) {
+				*errs = append(*errs, FieldError{Field: name, Rule: ruleName, Reason: "is required"})
+				return
+			}
+			continue
+		}
+
+		if reason := checkRule(value, ruleName, arg); reason != "" {
+			*errs = append(*errs, FieldError{Field: name, Rule: ruleName, Reason: reason})
+		}
+	}
+}
+
+// splitRules splits a validate tag into rules. The pattern rule takes the rest of the tag.
+func splitRules(tag string) []string {
+	var rules []string
+	for tag != "" {
+		if strings.HasPrefix(tag, "pattern=") {
+			return append(rules, tag)
+		}
+		rule := tag
+		if i := strings.Index(tag, ","); i >= 0 {
+			rule, tag = tag[:i], tag[i+1:]
+		} else {
+			tag = ""
+		}
+		if rule = strings.TrimSpace(rule); rule != "" {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// checkRule returns why v does not satisfy the rule, or an empty string if it does.
+func checkRule(v reflect.Value, rule, arg string) string {
+	switch rule {
+	case "min", "max":
+		limit, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return "has an invalid " + rule + " rule"
+		}
+		value, isLength := measure(v)
+		switch {
+		case rule == "min" && value < limit && isLength:
+			return "must have a length of at least " + arg
+		case rule == "min" && value < limit:
+			return "must be at least " + arg
+		case rule == "max" && value > limit && isLength:
+			return "must have a length of at most " + arg
+		case rule == "max" && value > limit:
+			return "must be at most " + arg
+		}
+	case "len":
+		n, err := strconv.Atoi(arg)
+		if err != nil {
+			return "has an invalid len rule"
+		}
+		if value, isLength := measure(v); !isLength || int(value) != n {
+			return "must have a length of " + arg
+		}
+	case "oneof":
+		value := fmt.Sprint(v.Interface())
+		for _, allowed := range strings.Fields(arg) {
+			if value == allowed {
+				return ""
+			}
+		}
+		return "must be one of " + strings.Join(strings.Fields(arg), ", ")
+	case "email":
+		if v.Kind() != reflect.String {
+			return "must be an email address"
+		}
+		if addr, err := mail.ParseAddress(v.String()); err != nil || addr.Address != v.String() {
+			return "must be an email address"
+		}
+	case "pattern":
+		re, err := compilePattern(arg)
+		if err != nil {
+			return "has an invalid pattern rule"
+		}
+		if v.Kind() != reflect.String || !re.MatchString(v.String()) {
+			return "must match " + arg
+		}
+	default:
+		return "has an unknown rule " + rule
+	}
+	return ""
+}
+
+// measure returns the value of numbers, or the length of strings, slices and maps, along with true for the latter.
+func measure(v reflect.Value) (float64, bool) {
+	switch v.Kind() {
+	case reflect.String:
+		return float64(utf8.RuneCountInString(v.String())), true
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return float64(v.Len()), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), false
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), false
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), false
+	}
+	return 0, false
+}
+
+func isEmpty(v reflect.Value) bool {
+	return !v.IsValid() || v.IsZero()
+}
+
+func indirect(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	return v
+}
+
+var patterns sync.Map // Compiled regular expressions of the pattern rules.
+
+func compilePattern(expr string) (*regexp.Regexp, error) {
+	if re, ok := patterns.Load(expr); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	patterns.Store(expr, re)
+	return re, nil
+}
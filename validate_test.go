@@ -0,0 +1,149 @@
+package minirouter
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type testAddress struct {
+	City string `json:"city" validate:"required"`
+	Zip  string `json:"zip" validate:"omitempty,len=5,pattern=^[0-9]{5}$"`
+}
+
+type testSignup struct {
+	Name     string        `json:"name" validate:"required,min=3,max=10"`
+	Email    string        `json:"email" validate:"required,email"`
+	Age      int           `json:"age" validate:"omitempty,min=18,max=130"`
+	Role     string        `json:"role" validate:"omitempty,oneof=admin user"`
+	Nickname *string       `json:"nickname" validate:"omitempty,min=2"`
+	Tags     []string      `json:"tags" validate:"max=2"`
+	Address  testAddress   `json:"address"`
+	Previous []testAddress `json:"previous"`
+}
+
+func TestValidateStruct(t *testing.T) {
+	short := "x"
+	tests := []struct {
+		name  string
+		input testSignup
+		want  []string
+	}{
+		{
+			name:  "Valid",
+			input: testSignup{Name: "john", Email: "john@example.com", Age: 42, Role: "admin", Address: testAddress{City: "Paris", Zip: "75001"}},
+		}, {
+			name:  "Missing required fields",
+			input: testSignup{},
+			want:  []string{"name:required", "email:required", "address.city:required"},
+		}, {
+			name: "Invalid values",
+			input: testSignup{
+				Name:     "jo",
+				Email:    "john",
+				Age:      12,
+				Role:     "root",
+				Nickname: &short,
+				Tags:     []string{"a", "b", "c"},
+				Address:  testAddress{City: "Paris", Zip: "7500A"},
+				Previous: []testAddress{{City: "Lyon"}, {Zip: "69001"}},
+			},
+			want: []string{
+				"name:min", "email:email", "age:min", "role:oneof", "nickname:min", "tags:max", "address.zip:pattern",
+				"previous[1].city:required",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateStruct(&tt.input)
+			if tt.want == nil {
+				assertNoError(t, err)
+				return
+			}
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected a *ValidationError, got %v", err)
+			}
+			var got []string
+			for _, f := range validationErr.Fields {
+				got = append(got, f.Field+":"+f.Rule)
+			}
+			if strings.Join(got, ", ") != strings.Join(tt.want, ", ") {
+				t.Errorf("Wrong invalid fields. Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestValidateStruct_zeroValues(t *testing.T) {
+	type order struct {
+		Qty      int     `json:"qty" validate:"min=1"`
+		Discount float64 `json:"discount" validate:"omitempty,min=5"`
+		Coupon   string  `json:"coupon" validate:"omitempty,len=8"`
+		Note     *string `json:"note" validate:"min=1"`
+	}
+
+	empty, note := "", "fragile"
+	tests := []struct {
+		name  string
+		input order
+		want  string
+	}{
+		{name: "Zero values", input: order{}, want: "qty:min, note:min"},
+		{name: "Empty pointed value", input: order{Qty: 1, Note: &empty}, want: "note:min"},
+		{name: "Valid", input: order{Qty: 1, Note: &note}},
+		{name: "Non-zero values", input: order{Qty: 1, Discount: 2, Coupon: "abc", Note: &note}, want: "discount:min, coupon:len"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateStruct(tt.input)
+			var got []string
+			var validationErr *ValidationError
+			if errors.As(err, &validationErr) {
+				for _, f := range validationErr.Fields {
+					got = append(got, f.Field+":"+f.Rule)
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			}
+			if strings.Join(got, ", ") != tt.want {
+				t.Errorf("Wrong invalid fields. Expected %s, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestValidationError_response(t *testing.T) {
+	r := New().WithErrorHandler(ProblemErrorHandler)
+	r.POST("/signup", r.E(func(w http.ResponseWriter, r *http.Request) error {
+		var input testSignup
+		if err := Bind(r, &input); err != nil {
+			return err
+		}
+		w.WriteHeader(http.StatusCreated)
+		return nil
+	}))
+
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	res, err := http.Post(srv.URL+"/signup", "application/json", strings.NewReader(`{"name":"jo","email":"john@example.com","address":{"city":"Paris"}}`))
+	assertNoError(t, err)
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusBadRequest {
+		t.Errorf("Wrong status code %d", res.StatusCode)
+	}
+	var body struct {
+		Status int          `json:"status"`
+		Errors []FieldError `json:"errors"`
+	}
+	assertNoError(t, json.NewDecoder(res.Body).Decode(&body))
+	if body.Status != http.StatusBadRequest || len(body.Errors) != 1 || body.Errors[0].Field != "name" || body.Errors[0].Reason != "must have a length of at least 3" {
+		t.Errorf("Wrong problem %+v", body)
+	}
+}
@@ -0,0 +1,136 @@
+package minirouter
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+// yamlNode is a JSON value decoded while preserving the order of object keys.
+type yamlNode struct {
+	scalar string // Raw JSON encoding of the value if it is neither an object nor an array.
+	isMap  bool
+	isList bool
+	keys   []string
+	values []*yamlNode
+}
+
+// jsonToYAML converts a JSON document into an equivalent YAML document, preserving the order of object keys.
+// Strings are written as double-quoted scalars, whose escaping rules are a superset of JSON's.
+func jsonToYAML(b []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	node, err := decodeYAMLNode(dec)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	switch {
+	case node.isMap && len(node.keys) > 0:
+		writeYAMLMap(&buf, node, 0)
+	case node.isList && len(node.values) > 0:
+		writeYAMLList(&buf, node, 0)
+	default:
+		buf.WriteString(yamlInline(node) + "\n")
+	}
+	return buf.Bytes(), nil
+}
+
+func decodeYAMLNode(dec *json.Decoder) (*yamlNode, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch tok := tok.(type) {
+	case json.Delim:
+		node := &yamlNode{isMap: tok == '{', isList: tok == '['}
+		for dec.More() {
+			if node.isMap {
+				key, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				node.keys = append(node.keys, key.(string))
+			}
+			value, err := decodeYAMLNode(dec)
+			if err != nil {
+				return nil, err
+			}
+			node.values = append(node.values, value)
+		}
+		if _, err := dec.Token(); err != nil { // Closing delimiter.
+			return nil, err
+		}
+		return node, nil
+	case nil:
+		return &yamlNode{scalar: "null"}, nil
+	default:
+		raw, err := json.Marshal(tok)
+		if err != nil {
+			return nil, err
+		}
+		return &yamlNode{scalar: string(raw)}, nil
+	}
+}
+
+func writeYAMLMap(buf *bytes.Buffer, node *yamlNode, indent int) {
+	pad := strings.Repeat(" ", indent)
+	for i, key := range node.keys {
+		value := node.values[i]
+		buf.WriteString(pad + yamlKey(key) + ":")
+		switch {
+		case value.isMap && len(value.keys) > 0:
+			buf.WriteString("\n")
+			writeYAMLMap(buf, value, indent+2)
+		case value.isList && len(value.values) > 0:
+			buf.WriteString("\n")
+			writeYAMLList(buf, value, indent+2)
+		default:
+			buf.WriteString(" " + yamlInline(value) + "\n")
+		}
+	}
+}
+
+func writeYAMLList(buf *bytes.Buffer, node *yamlNode, indent int) {
+	pad := strings.Repeat(" ", indent)
+	for _, value := range node.values {
+		if (value.isMap && len(value.keys) > 0) || (value.isList && len(value.values) > 0) {
+			// Write the nested collection one level deeper, then put the dash in place of the first indentation.
+			var nested bytes.Buffer
+			if value.isMap {
+				writeYAMLMap(&nested, value, indent+2)
+			} else {
+				writeYAMLList(&nested, value, indent+2)
+			}
+			buf.WriteString(pad + "- ")
+			buf.Write(nested.Bytes()[indent+2:])
+			continue
+		}
+		buf.WriteString(pad + "- " + yamlInline(value) + "\n")
+	}
+}
+
+// yamlInline returns the inline representation of a scalar or an empty collection.
+func yamlInline(node *yamlNode) string {
+	switch {
+	case node.isMap:
+		return "{}"
+	case node.isList:
+		return "[]"
+	default:
+		return node.scalar
+	}
+}
+
+var yamlPlainKey = regexp.MustCompile(`^[A-Za-z_/$][A-Za-z0-9_./${}-]*$`)
+
+func yamlKey(key string) string {
+	if yamlPlainKey.MatchString(key) {
+		return key
+	}
+	b, _ := json.Marshal(key)
+	return string(b)
+}